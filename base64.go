@@ -0,0 +1,47 @@
+package inworld
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Base64Bytes is a binary field encoded in base64 on the wire. Unlike []byte,
+// it accepts both the standard and the URL-safe alphabets, with or without
+// padding, as protobuf JSON encoding allows. SessionContinuation.PreviousState
+// is the only binary field of the API types: responses of the simple API carry
+// no audio, and the studio API refers to images and other assets by URL.
+type Base64Bytes []byte
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Base64Bytes) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, "json unmarshaling base64 bytes")
+	}
+
+	if s == nil {
+		*b = nil
+		return nil
+	}
+
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		if v, err := enc.DecodeString(*s); err == nil {
+			*b = v
+			return nil
+		}
+	}
+
+	return errors.Errorf("invalid base64 %q", limit([]byte(*s), 50))
+}
+
+// MarshalJSON implements json.Marshaler, the standard alphabet is used.
+func (b Base64Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.StdEncoding.EncodeToString(b))
+}
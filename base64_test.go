@@ -0,0 +1,70 @@
+package inworld
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestBase64BytesUnmarshalJSON(t *testing.T) {
+	want := []byte{0xfb, 0xff, 0xfe, 'a'}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "standard", data: `"+//+YQ=="`, want: want},
+		{name: "standard without padding", data: `"+//+YQ"`, want: want},
+		{name: "url-safe", data: `"-__-YQ=="`, want: want},
+		{name: "url-safe without padding", data: `"-__-YQ"`, want: want},
+		{name: "empty", data: `""`, want: []byte{}},
+		{name: "null", data: `null`, want: nil},
+		{name: "invalid character", data: `"YQ*="`, wantErr: true},
+		{name: "invalid length", data: `"Y"`, wantErr: true},
+		{name: "mixed alphabets", data: `"+_/-YQ=="`, wantErr: true},
+		{name: "number", data: `1`, wantErr: true},
+		{name: "array of bytes", data: `[1,2]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Base64Bytes
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %v, want an error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBase64BytesRoundTrip(t *testing.T) {
+	c := SessionContinuation{PreviousState: Base64Bytes{0xfb, 0xff, 0xfe, 'a'}}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"previousState":"+//+YQ=="}`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+
+	var got SessionContinuation
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.PreviousState, c.PreviousState) {
+		t.Errorf("got %v, want %v", got.PreviousState, c.PreviousState)
+	}
+}
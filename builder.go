@@ -0,0 +1,173 @@
+package inworld
+
+import (
+	"github.com/pkg/errors"
+)
+
+// CharacterBuilder builds a Character to be passed to CreateCharacter,
+// validating the values as they are set. The first invalid value is reported
+// by Build, all subsequent calls are ignored. The Character struct can still be
+// filled directly for the fields the builder doesn't cover.
+type CharacterBuilder struct {
+	ch  Character
+	err error
+}
+
+// NewCharacter starts building the character with the given name.
+func NewCharacter(givenName string) *CharacterBuilder {
+	b := &CharacterBuilder{}
+	if givenName == "" {
+		b.err = errors.New("given name is required")
+	}
+	b.ch.DefaultCharacterDescription.GivenName = givenName
+	return b
+}
+
+// WithDescription sets CharacterDescription.Description.
+func (b *CharacterBuilder) WithDescription(description string) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		ch.DefaultCharacterDescription.Description = description
+		return nil
+	})
+}
+
+// WithPronoun sets CharacterDescription.Pronoun.
+func (b *CharacterBuilder) WithPronoun(p Pronoun) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		ch.DefaultCharacterDescription.Pronoun = p
+		return nil
+	})
+}
+
+// WithMotivation sets CharacterDescription.Motivation.
+func (b *CharacterBuilder) WithMotivation(motivation string) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		ch.DefaultCharacterDescription.Motivation = motivation
+		return nil
+	})
+}
+
+// WithVoice sets CharacterAssets.Voice checking the documented ranges of
+// pitch, speaking rate and robotic filter level.
+func (b *CharacterBuilder) WithVoice(v Voice) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		if err := inRange("voice pitch", v.Pitch, -10, 10); err != nil {
+			return err
+		}
+		if err := inRange("voice speaking rate", v.SpeakingRate, 0, 5); err != nil {
+			return err
+		}
+		if err := inRange("voice robotic filter level", v.RoboticVoiceFilterLevel, 0, 5); err != nil {
+			return err
+		}
+
+		ch.DefaultCharacterAssets.Voice = v
+		return nil
+	})
+}
+
+// WithMood sets Character.InitialMood, see CharacterInitialMood.Validate.
+func (b *CharacterBuilder) WithMood(mood CharacterInitialMood) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		if err := mood.Validate(); err != nil {
+			return err
+		}
+
+		ch.InitialMood = mood
+		return nil
+	})
+}
+
+// WithPersonality sets Character.Personality, all sliders must be in the range
+// [-100, 100].
+func (b *CharacterBuilder) WithPersonality(p CharacterPersonality) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		for _, s := range []struct {
+			name  string
+			value int32
+		}{
+			{"positive", p.Positive},
+			{"peaceful", p.Peaceful},
+			{"open", p.Open},
+			{"extravert", p.Extravert},
+		} {
+			if err := inRange("personality "+s.name, float64(s.value), -100, 100); err != nil {
+				return err
+			}
+		}
+
+		ch.Personality = p
+		return nil
+	})
+}
+
+// WithSafety sets the safety level of the topic in Character.SafetyConfig.
+func (b *CharacterBuilder) WithSafety(topic SafetyTopic, level SafetyLevel) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		if topic == "" {
+			return errors.New("safety topic is required")
+		}
+
+		if ch.SafetyConfig == nil {
+			ch.SafetyConfig = SafetyConfigEntry{}
+		}
+		ch.SafetyConfig[string(topic)] = level
+		return nil
+	})
+}
+
+// WithFacts adds the facts to Character.PersonalKnowledge, see
+// PersonalKnowledge.Validate.
+func (b *CharacterBuilder) WithFacts(facts ...Fact) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		k := PersonalKnowledge{}
+		if ch.PersonalKnowledge != nil {
+			k = *ch.PersonalKnowledge
+		}
+		k.Facts = append(k.Facts, facts...)
+
+		if err := k.Validate(); err != nil {
+			return err
+		}
+
+		ch.PersonalKnowledge = &k
+		return nil
+	})
+}
+
+// WithCommonKnowledge adds the references to Character.CommonKnowledge. Format:
+// workspaces/{workspace}/common-knowledge/{common_knowledge}
+func (b *CharacterBuilder) WithCommonKnowledge(names ...string) *CharacterBuilder {
+	return b.set(func(ch *Character) error {
+		ch.CommonKnowledge = append(ch.CommonKnowledge, names...)
+		return nil
+	})
+}
+
+// Build returns the built character or the first error found, see
+// Character.Validate.
+func (b *CharacterBuilder) Build() (Character, error) {
+	if b.err != nil {
+		return Character{}, b.err
+	}
+
+	if err := b.ch.Validate(); err != nil {
+		return Character{}, err
+	}
+
+	return b.ch, nil
+}
+
+func (b *CharacterBuilder) set(f func(*Character) error) *CharacterBuilder {
+	if b.err == nil {
+		b.err = f(&b.ch)
+	}
+	return b
+}
+
+func inRange(name string, v, lo, hi float64) error {
+	if v < lo || v > hi {
+		return errors.Errorf("%s must be in range [%v, %v], got %v", name, lo, hi, v)
+	}
+	return nil
+}
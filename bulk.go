@@ -0,0 +1,318 @@
+package inworld
+
+import (
+	"context"
+	stderrors "errors"
+	"path"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrConfirmationRequired is returned by DeleteAllCharacters when the
+// deletion isn't confirmed with ConfirmWorkspace or allowed with Unsafe.
+var ErrConfirmationRequired = stderrors.New("confirmation of the deletion is required")
+
+// DeleteAllOption configures DeleteAllCharacters.
+type DeleteAllOption func(*deleteAllOptions)
+
+type deleteAllOptions struct {
+	confirmedWorkspace string
+	unsafe             bool
+}
+
+// ConfirmWorkspace confirms the deletion of everything in the workspace, it
+// must be the same as the workspace being cleared.
+func ConfirmWorkspace(workspaceID string) DeleteAllOption {
+	return func(o *deleteAllOptions) { o.confirmedWorkspace = workspaceID }
+}
+
+// Unsafe allows the deletion without confirmation, for automation accepting
+// the risk of clearing a wrong workspace.
+func Unsafe() DeleteAllOption {
+	return func(o *deleteAllOptions) { o.unsafe = true }
+}
+
+// DeleteAllCharacters deletes all characters of the workspace running up to
+// concurrency deletions at once. The deletion must be confirmed with
+// ConfirmWorkspace naming the same workspace (or allowed with Unsafe),
+// otherwise ErrConfirmationRequired is returned and nothing is deleted.
+// Characters deleted by someone else in the meantime are skipped. Deletion
+// stops when the context is canceled, the returned error joins the errors of
+// all failed deletions.
+func (c Client) DeleteAllCharacters(
+	ctx context.Context,
+	workspaceID string,
+	concurrency int,
+	opts ...DeleteAllOption,
+) (deleted int, err error) {
+	if workspaceID, err = c.workspace(workspaceID); err != nil {
+		return 0, err
+	}
+
+	var o deleteAllOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !o.unsafe && o.confirmedWorkspace != workspaceID {
+		return 0, errors.Wrapf(ErrConfirmationRequired, "deleting all characters of workspace %s", workspaceID)
+	}
+
+	// Names are collected before the deletion, otherwise deleted characters
+	// would shift the pages.
+	var names []string
+	err = c.RangeCharacters(ctx, GetCharactersRequest{WorkspaceID: workspaceID}, func(ch Character) error {
+		names = append(names, ch.Name)
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "listing characters")
+	}
+
+	var mu sync.Mutex
+	err = runConcurrently(ctx, len(names), concurrency, func(i int) error {
+		err := c.DeleteCharacter(ctx, names[i])
+		if stderrors.Is(err, ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "deleting %s", names[i])
+		}
+
+		mu.Lock()
+		deleted++
+		mu.Unlock()
+
+		return nil
+	})
+
+	return deleted, err
+}
+
+// FindDuplicateCharacters returns the characters of the workspace sharing the
+// same CharacterDescription.GivenName grouped by the name, names used by a
+// single character are omitted. The characters are listed page by page, only
+// the first character of each name is kept until a duplicate is found.
+func (c Client) FindDuplicateCharacters(ctx context.Context, workspaceID string) (map[string][]Character, error) {
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	first := map[string]Character{}
+	dups := map[string][]Character{}
+	err = c.RangeCharacters(ctx, GetCharactersRequest{WorkspaceID: workspaceID}, func(ch Character) error {
+		name := ch.DefaultCharacterDescription.GivenName
+
+		prev, ok := first[name]
+		switch {
+		case !ok:
+			first[name] = ch
+		case len(dups[name]) == 0:
+			dups[name] = []Character{prev, ch}
+		default:
+			dups[name] = append(dups[name], ch)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dups, nil
+}
+
+// AssignVoice sets the voice of the characters running up to concurrency
+// updates at once, other fields of the characters are not changed. All the
+// characters must belong to the same workspace, and the voice must be one of
+// ListVoices of that workspace. The names and the catalog are checked before
+// any character is updated. Updated characters are returned in the order of
+// the names, a zero Character stands for each failed one. The returned error
+// joins the errors of all failed updates.
+func (c Client) AssignVoice(
+	ctx context.Context,
+	characterNames []string,
+	v Voice,
+	concurrency int,
+) ([]Character, error) {
+	if len(characterNames) == 0 {
+		return nil, nil
+	}
+
+	var workspaceID string
+	for _, name := range characterNames {
+		ws, err := resourceWorkspace(name, ResourceKindCharacter)
+		if err != nil {
+			return nil, err
+		}
+
+		if workspaceID == "" {
+			workspaceID = ws
+		} else if ws != workspaceID {
+			return nil, errors.Errorf("characters of workspaces %s and %s can't be updated at once", workspaceID, ws)
+		}
+	}
+
+	voices, err := c.ListVoices(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing voices")
+	}
+
+	known := slices.ContainsFunc(voices, func(sv StudioBaseVoice) bool {
+		return (sv.Name == v.BaseName || path.Base(sv.Name) == v.BaseName) &&
+			(v.TTSType == "" || sv.TTSType == v.TTSType)
+	})
+	if !known {
+		return nil, errors.Errorf("voice %q of type %q is not available in workspace %s", v.BaseName, v.TTSType, workspaceID)
+	}
+
+	res := make([]Character, len(characterNames))
+	err = runConcurrently(ctx, len(characterNames), concurrency, func(i int) error {
+		ch, err := c.patchCharacter(
+			ctx,
+			characterNames[i],
+			Character{DefaultCharacterAssets: CharacterAssets{Voice: v}},
+			"defaultCharacterAssets.voice",
+		)
+		if err != nil {
+			return errors.Wrapf(err, "assigning voice to %s", characterNames[i])
+		}
+
+		res[i] = ch
+		return nil
+	})
+
+	return res, err
+}
+
+// namesPerFilter limits the number of names in a single filter of
+// GetCharactersByNames to keep the URL well below the usual limit of 8KB.
+const namesPerFilter = 20
+
+// GetCharactersByNames returns the characters with the given resource names
+// in the order of the names, requesting them in batches with the names filter.
+// Format of the name: workspaces/{workspace}/characters/{character}. Names of
+// the characters that don't exist are returned as missing, duplicates are
+// returned once.
+func (c Client) GetCharactersByNames(
+	ctx context.Context,
+	workspaceID string,
+	names []string,
+) (found []Character, missing []string, err error) {
+	if workspaceID, err = c.workspace(workspaceID); err != nil {
+		return nil, nil, err
+	}
+
+	byName := make(map[string]Character, len(names))
+	for start := 0; start < len(names); start += namesPerFilter {
+		filters := make([]Filter, 0, namesPerFilter)
+		for _, name := range names[start:min(start+namesPerFilter, len(names))] {
+			filters = append(filters, FilterByName(name))
+		}
+
+		filter, err := FilterOr(filters...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req := GetCharactersRequest{WorkspaceID: workspaceID, Filter: filter.String()}
+		err = c.RangeCharacters(ctx, req, func(ch Character) error {
+			byName[ch.Name] = ch
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if ch, ok := byName[name]; ok {
+			found = append(found, ch)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	return found, missing, nil
+}
+
+// runConcurrently calls f for each index in [0, n) running up to concurrency
+// calls at once (1 if concurrency isn't positive). Calls that haven't started
+// by the time ctx is canceled are skipped. The returned error joins all errors
+// returned by f and the context error, if any.
+func runConcurrently(ctx context.Context, n, concurrency int, f func(i int) error) error {
+	i := 0
+	return runStream(ctx, concurrency, func() (func() error, bool, error) {
+		if i >= n {
+			return nil, false, nil
+		}
+		j := i
+		i++
+		return func() error { return f(j) }, true, nil
+	})
+}
+
+// runStream is runConcurrently for jobs that are not known in advance, e.g.
+// read from a stream. next is called sequentially before each job is started
+// and returns the job, false when there are no more jobs, or an error, which
+// stops producing jobs. The jobs started by then are waited for, the returned
+// error joins the errors of next, the jobs and the context.
+func runStream(ctx context.Context, concurrency int, next func() (job func() error, ok bool, err error)) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+
+	addErr := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	for {
+		job, ok, err := next()
+		if err != nil {
+			addErr(err)
+			break
+		}
+		if !ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			addErr(err)
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			if err := job(); err != nil {
+				addErr(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return stderrors.Join(errs...)
+}
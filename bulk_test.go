@@ -0,0 +1,61 @@
+package inworld
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAssignVoice(t *testing.T) {
+	var patches atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"voices":[{"name":"workspaces/w/voices/Alex"}]}`))
+		case http.MethodPatch:
+			patches.Add(1)
+			if got := r.URL.Query().Get("updateMask"); got != "defaultCharacterAssets.voice" {
+				t.Errorf("updateMask is %q", got)
+			}
+			_, _ = w.Write([]byte(`{"name":"` + strings.TrimPrefix(r.URL.Path, "/studio/v1/") + `"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	res, err := c.AssignVoice(context.Background(), []string{
+		"workspaces/w/characters/a",
+		"workspaces/w/characters/b",
+	}, Voice{BaseName: "Alex"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 || res[0].Name != "workspaces/w/characters/a" || res[1].Name != "workspaces/w/characters/b" {
+		t.Errorf("unexpected characters %+v", res)
+	}
+	if n := patches.Load(); n != 2 {
+		t.Errorf("%d characters are updated, want 2", n)
+	}
+}
+
+func TestAssignVoiceChecksNamesFirst(t *testing.T) {
+	tests := map[string][]string{
+		"different workspaces": {"workspaces/w/characters/a", "workspaces/other/characters/b"},
+		"invalid name":         {"workspaces/w/characters/a", "workspaces/w/characters/b", "b"},
+		"not a character":      {"workspaces/w/characters/a", "workspaces/w/scenes/s"},
+	}
+
+	for name, names := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			})
+
+			if _, err := c.AssignVoice(context.Background(), names, Voice{BaseName: "Alex"}, 2); err == nil {
+				t.Error("no error")
+			}
+		})
+	}
+}
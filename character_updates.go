@@ -0,0 +1,141 @@
+package inworld
+
+import (
+	"context"
+	stderrors "errors"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// AddCustomDialogStyle adds the custom dialog style to the character. Other
+// fields of the character are not changed.
+func (c Client) AddCustomDialogStyle(
+	ctx context.Context,
+	characterName string,
+	style CustomDialogStyle,
+) (Character, error) {
+	if err := style.Validate(); err != nil {
+		return Character{}, err
+	}
+
+	ch, err := c.GetCharacter(ctx, characterName, "")
+	if err != nil {
+		return Character{}, errors.Wrap(err, "getting character")
+	}
+
+	ch.DefaultCharacterDescription.CustomDialogStyles = append(
+		ch.DefaultCharacterDescription.CustomDialogStyles,
+		style,
+	)
+
+	return c.patchCharacter(ctx, characterName, ch, "defaultCharacterDescription.customDialogStyles")
+}
+
+// SetCustomDialogStyleActive enables or disables the custom dialog style with
+// the given UUID. Other fields of the character are not changed.
+func (c Client) SetCustomDialogStyleActive(
+	ctx context.Context,
+	characterName, uuid string,
+	active bool,
+) (Character, error) {
+	if uuid == "" {
+		return Character{}, stderrors.New("custom dialog style uuid is required")
+	}
+
+	ch, err := c.GetCharacter(ctx, characterName, "")
+	if err != nil {
+		return Character{}, errors.Wrap(err, "getting character")
+	}
+
+	styles := ch.DefaultCharacterDescription.CustomDialogStyles
+	i := slices.IndexFunc(styles, func(s CustomDialogStyle) bool { return s.UUID == uuid })
+	if i < 0 {
+		return Character{}, errors.Errorf("custom dialog style %q not found", uuid)
+	}
+
+	styles[i].IsActive = active
+
+	return c.patchCharacter(ctx, characterName, ch, "defaultCharacterDescription.customDialogStyles")
+}
+
+// SetLongTermCoherence enables or disables the long-term coherence of the
+// character. Other fields of the character are not changed.
+func (c Client) SetLongTermCoherence(ctx context.Context, characterName string, enabled bool) (Character, error) {
+	return c.patchCharacter(
+		ctx,
+		characterName,
+		Character{LongTermCoherence: LongTermCoherence{Enabled: enabled}},
+		"longTermCoherence",
+	)
+}
+
+// AddPersonalKnowledgeFacts appends the facts to the personal knowledge of the
+// character. The resulting personal knowledge is validated before the update,
+// see PersonalKnowledge.Validate. Other fields of the character are not
+// changed.
+func (c Client) AddPersonalKnowledgeFacts(ctx context.Context, characterName string, facts ...Fact) (Character, error) {
+	ch, err := c.GetCharacter(ctx, characterName, "")
+	if err != nil {
+		return Character{}, errors.Wrap(err, "getting character")
+	}
+
+	if ch.PersonalKnowledge == nil {
+		ch.PersonalKnowledge = &PersonalKnowledge{}
+	}
+	ch.PersonalKnowledge.Facts = append(ch.PersonalKnowledge.Facts, facts...)
+
+	if err = ch.PersonalKnowledge.Validate(); err != nil {
+		return Character{}, err
+	}
+
+	return c.patchCharacter(ctx, characterName, ch, "personalKnowledge")
+}
+
+// SetInitialMood sets the initial mood of the character, see
+// CharacterInitialMood.Validate. Other fields of the character are not
+// changed.
+func (c Client) SetInitialMood(
+	ctx context.Context,
+	characterName string,
+	mood CharacterInitialMood,
+) (Character, error) {
+	if err := mood.Validate(); err != nil {
+		return Character{}, err
+	}
+
+	return c.patchCharacter(ctx, characterName, Character{InitialMood: mood}, "initialMood")
+}
+
+// SetEmotionalFluidity sets the emotional fluidity of the character, the value
+// must be in the range [0, 1]. Other fields of the character are not changed.
+func (c Client) SetEmotionalFluidity(ctx context.Context, characterName string, fluidity float32) (Character, error) {
+	if fluidity < 0 || fluidity > 1 {
+		return Character{}, errors.Errorf("emotional fluidity must be in range [0, 1], got %v", fluidity)
+	}
+
+	return c.patchCharacter(ctx, characterName, Character{EmotionalFluidity: fluidity}, "emotionalFluidity")
+}
+
+// SetSocialRank sets the social rank (the insecure/confident slider) of the
+// character, the value must be in the range [0, 1]. Other fields of the
+// character are not changed.
+func (c Client) SetSocialRank(ctx context.Context, characterName string, rank float32) (Character, error) {
+	if rank < 0 || rank > 1 {
+		return Character{}, errors.Errorf("social rank must be in range [0, 1], got %v", rank)
+	}
+
+	return c.patchCharacter(ctx, characterName, Character{SocialRank: rank}, "socialRank")
+}
+
+// SetNarrativeActions enables or disables the narrated actions in the responses
+// of the character, see Interaction.Segments. Other fields of the character
+// are not changed.
+func (c Client) SetNarrativeActions(ctx context.Context, characterName string, enabled bool) (Character, error) {
+	return c.patchCharacter(
+		ctx,
+		characterName,
+		Character{DefaultCharacterDescription: CharacterDescription{NarrativeActionsEnabled: enabled}},
+		"defaultCharacterDescription.narrativeActionsEnabled",
+	)
+}
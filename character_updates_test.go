@@ -0,0 +1,210 @@
+package inworld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestYamlConfigValidationFollowsMask(t *testing.T) {
+	stored := Character{
+		Name:       "workspaces/w/characters/c",
+		YamlConfig: "goals: [greet\n",
+	}
+
+	var patches atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			patches.Add(1)
+		}
+		_ = json.NewEncoder(w).Encode(stored)
+	}, WithYamlConfigValidation())
+
+	ctx := context.Background()
+
+	if _, err := c.AddCustomDialogStyle(ctx, stored.Name, CustomDialogStyle{DisplayName: "curt"}); err != nil {
+		t.Errorf("update without yamlConfig in the mask failed: %v", err)
+	}
+
+	if _, err := c.UpdateCharacter(ctx, stored.Name, stored); err == nil {
+		t.Error("update of the whole character with a malformed config succeeded")
+	}
+
+	if n := patches.Load(); n != 1 {
+		t.Errorf("%d patches sent, want 1", n)
+	}
+}
+
+func TestCharacterUpdateHelpers(t *testing.T) {
+	const name = "workspaces/w/characters/c"
+
+	stored := func() Character {
+		return Character{
+			Name: name,
+			DefaultCharacterDescription: CharacterDescription{
+				GivenName:          "Ada",
+				CustomDialogStyles: []CustomDialogStyle{{UUID: "u1", DisplayName: "formal", IsActive: true}},
+			},
+			PersonalKnowledge: &PersonalKnowledge{Facts: []Fact{{Text: "Born in 1815."}}},
+			InitialMood:       CharacterInitialMood{Joy: 10},
+			SocialRank:        0.1,
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		call func(c Client) (Character, error)
+		mask string
+		// Applies the masked field of the body to the stored character the
+		// way the server does.
+		apply func(stored *Character, body Character)
+		want  func(ch *Character)
+	}{
+		{
+			name: "AddCustomDialogStyle",
+			call: func(c Client) (Character, error) {
+				return c.AddCustomDialogStyle(context.Background(), name, CustomDialogStyle{DisplayName: "curt"})
+			},
+			mask: "defaultCharacterDescription.customDialogStyles",
+			apply: func(stored *Character, body Character) {
+				stored.DefaultCharacterDescription.CustomDialogStyles = body.DefaultCharacterDescription.CustomDialogStyles
+			},
+			want: func(ch *Character) {
+				ch.DefaultCharacterDescription.CustomDialogStyles = append(
+					ch.DefaultCharacterDescription.CustomDialogStyles,
+					CustomDialogStyle{DisplayName: "curt"},
+				)
+			},
+		},
+		{
+			name: "SetCustomDialogStyleActive",
+			call: func(c Client) (Character, error) {
+				return c.SetCustomDialogStyleActive(context.Background(), name, "u1", false)
+			},
+			mask: "defaultCharacterDescription.customDialogStyles",
+			apply: func(stored *Character, body Character) {
+				stored.DefaultCharacterDescription.CustomDialogStyles = body.DefaultCharacterDescription.CustomDialogStyles
+			},
+			want: func(ch *Character) { ch.DefaultCharacterDescription.CustomDialogStyles[0].IsActive = false },
+		},
+		{
+			name: "SetLongTermCoherence",
+			call: func(c Client) (Character, error) {
+				return c.SetLongTermCoherence(context.Background(), name, true)
+			},
+			mask:  "longTermCoherence",
+			apply: func(stored *Character, body Character) { stored.LongTermCoherence = body.LongTermCoherence },
+			want:  func(ch *Character) { ch.LongTermCoherence.Enabled = true },
+		},
+		{
+			name: "AddPersonalKnowledgeFacts",
+			call: func(c Client) (Character, error) {
+				return c.AddPersonalKnowledgeFacts(context.Background(), name, Fact{Text: "Wrote the first program."})
+			},
+			mask:  "personalKnowledge",
+			apply: func(stored *Character, body Character) { stored.PersonalKnowledge = body.PersonalKnowledge },
+			want: func(ch *Character) {
+				ch.PersonalKnowledge.Facts = append(ch.PersonalKnowledge.Facts, Fact{Text: "Wrote the first program."})
+			},
+		},
+		{
+			name: "SetInitialMood",
+			call: func(c Client) (Character, error) {
+				return c.SetInitialMood(context.Background(), name, CharacterInitialMood{Joy: -20, Trust: 50})
+			},
+			mask:  "initialMood",
+			apply: func(stored *Character, body Character) { stored.InitialMood = body.InitialMood },
+			want:  func(ch *Character) { ch.InitialMood = CharacterInitialMood{Joy: -20, Trust: 50} },
+		},
+		{
+			name: "SetEmotionalFluidity",
+			call: func(c Client) (Character, error) {
+				return c.SetEmotionalFluidity(context.Background(), name, 0.5)
+			},
+			mask:  "emotionalFluidity",
+			apply: func(stored *Character, body Character) { stored.EmotionalFluidity = body.EmotionalFluidity },
+			want:  func(ch *Character) { ch.EmotionalFluidity = 0.5 },
+		},
+		{
+			name: "SetSocialRank",
+			call: func(c Client) (Character, error) {
+				return c.SetSocialRank(context.Background(), name, 0.75)
+			},
+			mask:  "socialRank",
+			apply: func(stored *Character, body Character) { stored.SocialRank = body.SocialRank },
+			want:  func(ch *Character) { ch.SocialRank = 0.75 },
+		},
+		{
+			name: "SetNarrativeActions",
+			call: func(c Client) (Character, error) {
+				return c.SetNarrativeActions(context.Background(), name, true)
+			},
+			mask: "defaultCharacterDescription.narrativeActionsEnabled",
+			apply: func(stored *Character, body Character) {
+				stored.DefaultCharacterDescription.NarrativeActionsEnabled = body.DefaultCharacterDescription.NarrativeActionsEnabled
+			},
+			want: func(ch *Character) { ch.DefaultCharacterDescription.NarrativeActionsEnabled = true },
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_ = json.NewEncoder(w).Encode(stored())
+					return
+				}
+
+				if r.Method != http.MethodPatch {
+					t.Errorf("method is %s, want PATCH", r.Method)
+				}
+				if got := r.URL.Query().Get("updateMask"); got != tt.mask {
+					t.Errorf("updateMask is %q, want %q", got, tt.mask)
+				}
+
+				var body Character
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+					return
+				}
+
+				updated := stored()
+				tt.apply(&updated, body)
+				_ = json.NewEncoder(w).Encode(updated)
+			})
+
+			got, err := tt.call(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := stored()
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCharacterValidatedBeforeSending(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	})
+
+	ch := Character{DefaultCharacterDescription: CharacterDescription{
+		CustomDialogStyles: []CustomDialogStyle{{Adjectives: []string{"anxious", "curt", "expressive", "hilarious"}}},
+	}}
+
+	if _, err := c.CreateCharacter(context.Background(), "w", ch); err == nil {
+		t.Error("CreateCharacter accepted an invalid character")
+	}
+	if _, err := c.UpdateCharacter(context.Background(), "workspaces/w/characters/c", ch); err == nil {
+		t.Error("UpdateCharacter accepted an invalid character")
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d requests sent", n)
+	}
+}
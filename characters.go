@@ -2,9 +2,14 @@ package inworld
 
 import (
 	"context"
+	"encoding/json"
 	stderrors "errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 )
@@ -14,27 +19,47 @@ import (
 
 // CreateCharacter initiates the creation of a character that requires
 // subsequent deployment for activation. The character can't be used in
-// conversation until it is deployed.
+// conversation until it is deployed. The character is checked with
+// Character.Validate before it is sent.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#create-character
 func (c Client) CreateCharacter(ctx context.Context, workspaceID string, ch Character) (Character, error) {
-	if workspaceID == "" {
-		return Character{}, stderrors.New("workspace id is required")
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return Character{}, err
+	}
+
+	if err := ch.Validate(); err != nil {
+		return Character{}, err
+	}
+
+	if c.validateYamlConfig {
+		if err := ValidateYamlConfig(ch.YamlConfig); err != nil {
+			return Character{}, err
+		}
 	}
 
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
 		apiStudioV1.JoinPath("workspaces", workspaceID, "characters").String(),
-		newReader(ch),
+		newReader(ch.forRequest()),
 	)
 	if err != nil {
 		return Character{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[Character](c, r)
+	return withValidationError(sendStudioAPIRequest[Character](c, r))
 }
 
 // GetCharacter returns a specific character within a workspace.
+//
+// The Studio API always returns the draft of the character, i.e. the version
+// with all changes made via Studio or the API, whether they are deployed or
+// not. The deployed version, which is used in conversations, can't be read and
+// the character has no indicator of undeployed changes. Tooling that needs to
+// know whether a deployment is required can keep the result of GetCharacter
+// taken right after DeployCharacter and compare it with the current draft using
+// Character.Diff, or check LatestDeploymentStatus.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#get-character
 func (c Client) GetCharacter(
 	ctx context.Context,
@@ -66,18 +91,36 @@ func (c Client) GetCharacter(
 	return sendStudioAPIRequest[Character](c, r)
 }
 
+// LoadMeta sets the Meta of the character listed without it, e.g. with the
+// default view of GetCharacters, fetching the character with
+// CharacterItemViewWithMeta. Other fields of the character are not changed.
+func (c Client) LoadMeta(ctx context.Context, ch *Character) error {
+	if ch == nil {
+		return stderrors.New("character is required")
+	}
+
+	withMeta, err := c.GetCharacter(ctx, ch.Name, CharacterItemViewWithMeta)
+	if err != nil {
+		return err
+	}
+
+	ch.Meta = withMeta.Meta
+	return nil
+}
+
 // DeployCharacter asynchronously deploys the character. The deployment process
 // is managed as a long-running operation (LRO). The progress and result of this
-// operation should be monitored using the returned LRO object. Upon successful
-// completion, all characters will reflect the updates during their
-// interactions. In the event of a failure, the operation should be retried.
+// operation should be monitored using the returned LRO object, see
+// PollOperation. Upon successful completion, all characters will reflect the
+// updates during their interactions. In the event of a failure, the operation
+// should be retried.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#deploy-character
 func (c Client) DeployCharacter(
 	ctx context.Context,
 	characterName string,
-) (DeploymentResponse, error) {
+) (Operation, error) {
 	if characterName == "" {
-		return DeploymentResponse{}, stderrors.New("character name is required")
+		return Operation{}, stderrors.New("character name is required")
 	}
 
 	r, err := http.NewRequestWithContext(
@@ -87,10 +130,36 @@ func (c Client) DeployCharacter(
 		http.NoBody,
 	)
 	if err != nil {
-		return DeploymentResponse{}, errors.WithStack(err)
+		return Operation{}, errors.WithStack(err)
+	}
+
+	return startOperation(c, r)
+}
+
+// RenderAvatarImages asynchronously renders the 2D images of the character's
+// ReadyPlayerMe avatar. Only characters with AvatarTypeRPM and a non-empty
+// RPMAvatar.RPMModelURI can be rendered, Innequin avatars and user provided
+// images are not affected. The rendering is managed as a long-running
+// operation (LRO), its status can be polled with PollOperation. Upon
+// successful completion RPMImageURI, RPMImageURIPortrait and RPMImageURIPosture
+// of the character's RPMAvatar are populated.
+// There is no documentation for this method.
+func (c Client) RenderAvatarImages(ctx context.Context, characterName string) (Operation, error) {
+	if characterName == "" {
+		return Operation{}, stderrors.New("character name is required")
+	}
+
+	r, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		apiStudioV1.JoinPath(characterName+":renderAvatarImages").String(),
+		http.NoBody,
+	)
+	if err != nil {
+		return Operation{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[DeploymentResponse](c, r)
+	return startOperation(c, r)
 }
 
 // GetCharacters returns a list of characters that can be filtered by several
@@ -98,6 +167,22 @@ func (c Client) DeployCharacter(
 // initially remain unchanged.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#list-characters
 func (c Client) GetCharacters(ctx context.Context, req GetCharactersRequest) (GetCharactersResponse, error) {
+	workspaceID, err := c.workspace(req.WorkspaceID)
+	if err != nil {
+		return GetCharactersResponse{}, err
+	}
+	req.WorkspaceID = workspaceID
+
+	if req.PageSize, err = c.pageSize(req.PageSize); err != nil {
+		return GetCharactersResponse{}, err
+	}
+
+	fp := req.fingerprint()
+	pageToken, err := req.Cursor.token(fp, req.PageToken)
+	if err != nil {
+		return GetCharactersResponse{}, errors.WithStack(err)
+	}
+
 	url := apiStudioV1.JoinPath("workspaces", req.WorkspaceID, "characters")
 	q := url.Query()
 	if req.View != "" {
@@ -106,8 +191,8 @@ func (c Client) GetCharacters(ctx context.Context, req GetCharactersRequest) (Ge
 	if req.PageSize > 0 {
 		q.Add("pageSize", strconv.FormatInt(int64(req.PageSize), 10))
 	}
-	if req.PageToken != "" {
-		q.Add("pageToken", req.PageToken)
+	if pageToken != "" {
+		q.Add("pageToken", pageToken)
 	}
 	if req.Filter != "" {
 		q.Add("filter", req.Filter)
@@ -125,31 +210,70 @@ func (c Client) GetCharacters(ctx context.Context, req GetCharactersRequest) (Ge
 		return GetCharactersResponse{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[GetCharactersResponse](c, r)
+	resp, err := sendStudioAPIRequest[GetCharactersResponse](c, r)
+	resp.NextPageCursor = PageCursor{Token: resp.NextPageToken, fingerprint: fp}
+	return resp, err
 }
 
 // UpdateCharacter updates the specified character. Changes to the character are
-// not reflected in conversation until the character is deployed.
+// not reflected in conversation until the character is deployed. Output-only
+// fields of upd (e.g. Name and Meta) are not sent, so a character returned by
+// GetCharacter can be sent back as is. upd is checked with Character.Validate
+// before it is sent.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#update-character
 func (c Client) UpdateCharacter(ctx context.Context, characterName string, upd Character) (Character, error) {
+	if err := upd.Validate(); err != nil {
+		return Character{}, err
+	}
+
+	return c.patchCharacter(ctx, characterName, upd)
+}
+
+// patchCharacter updates the character. When updateMask is not empty, only the
+// listed fields (JSON paths, e.g. "initialMood") are changed.
+func (c Client) patchCharacter(
+	ctx context.Context,
+	characterName string,
+	upd Character,
+	updateMask ...string,
+) (Character, error) {
 	if characterName == "" {
 		return Character{}, stderrors.New("character name cannot be empty")
 	}
 
+	// The config isn't sent unless the mask is empty or lists it, so an
+	// unrelated update must not fail because of it.
+	if c.validateYamlConfig && (len(updateMask) == 0 || slices.Contains(updateMask, "yamlConfig")) {
+		if err := ValidateYamlConfig(upd.YamlConfig); err != nil {
+			return Character{}, err
+		}
+	}
+
+	url := apiStudioV1.JoinPath(characterName)
+	if len(updateMask) > 0 {
+		q := url.Query()
+		q.Add("updateMask", strings.Join(updateMask, ","))
+		url.RawQuery = q.Encode()
+	}
+
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPatch,
-		apiStudioV1.JoinPath(characterName).String(),
-		newReader(upd),
+		url.String(),
+		newReader(upd.forRequest()),
 	)
 	if err != nil {
 		return Character{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[Character](c, r)
+	return withValidationError(sendStudioAPIRequest[Character](c, r))
 }
 
-// DeleteCharacter deletes a specific character within a workspace.
+// DeleteCharacter deletes a specific character within a workspace. The
+// deletion is permanent: the Studio API has no archive (soft delete) or restore
+// methods and characters carry no deleted/archived state, so tooling that needs
+// undo should keep a copy of the character (e.g. the result of GetCharacter)
+// and recreate it with CreateCharacter.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#delete-character
 func (c Client) DeleteCharacter(ctx context.Context, characterName string) error {
 	if characterName == "" {
@@ -170,12 +294,32 @@ func (c Client) DeleteCharacter(ctx context.Context, characterName string) error
 	return err
 }
 
+// GetInteractionStats returns the number of interactions with the character.
+// There is no documentation for this method.
+func (c Client) GetInteractionStats(ctx context.Context, characterName string) (InteractionCountStat, error) {
+	if _, err := resourceWorkspace(characterName, ResourceKindCharacter); err != nil {
+		return InteractionCountStat{}, err
+	}
+
+	r, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		apiStudioV1.JoinPath(characterName, "interactionCountStat").String(),
+		http.NoBody,
+	)
+	if err != nil {
+		return InteractionCountStat{}, errors.WithStack(err)
+	}
+
+	return sendStudioAPIRequest[InteractionCountStat](c, r)
+}
+
 // GetCharactersRequest represents a request for retrieving characters.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#request-body-2
 type GetCharactersRequest struct {
-	WorkspaceID string // Required.
+	WorkspaceID string // Required, unless set with WithDefaultWorkspace.
 	// Max number of items to retrieve per page. Default is 50.
-	PageSize int32 // Optional.
+	PageSize int32 // Optional, see MaxPageSize.
 	// A page token received from a previous GetCharactersResponse. Provide this to
 	// retrieve the subsequent page. When paginating, all other parameters provided
 	// to GetCharacters must remain the same.
@@ -194,7 +338,19 @@ type GetCharactersRequest struct {
 	// 	instance, to filter by two different characters, use
 	// 	character.name=workspaces/{workspace_id}/character/{uuid1} OR
 	// 	character.name=workspaces/{workspace_id}/character/{uuid2}.
+	//
+	// See Filter for the helpers building filter expressions.
 	Filter string // Optional.
+	// A cursor received from a previous GetCharactersResponse. Takes precedence
+	// over PageToken, unlike the raw token GetCharacters returns
+	// ErrInconsistentPagination if other parameters have changed, and
+	// ErrNoMorePages for the cursor of the last page.
+	Cursor PageCursor // Optional.
+}
+
+func (req GetCharactersRequest) fingerprint() string {
+	req.PageToken, req.Cursor = "", PageCursor{}
+	return fingerprint(req)
 }
 
 // GetCharactersResponse represents the response object for the GetCharacters
@@ -203,6 +359,8 @@ type GetCharactersRequest struct {
 type GetCharactersResponse struct {
 	Characters    []Character `json:"characters"`
 	NextPageToken string      `json:"nextPageToken"`
+	// NextPageCursor is NextPageToken bound to the parameters of the request.
+	NextPageCursor PageCursor `json:"-"`
 }
 
 // Character represents a character with various properties and configurations.
@@ -211,16 +369,21 @@ type Character struct {
 	// Name is an automatically generated resource name by the system based on
 	// given_name property. This field can't be set or changed via API.
 	Name string `json:"name,omitempty"` // Optional.
+	// Time of the creation and of the last update, nil if not returned by the
+	// server. These fields can't be set or changed via API.
+	// There is no documentation for these fields.
+	CreateTime *time.Time `json:"createTime,omitempty"` // Optional.
+	UpdateTime *time.Time `json:"updateTime,omitempty"` // Optional.
 	// DefaultCharacterDescription provides structured default character
 	// description.
-	DefaultCharacterDescription CharacterDescription `json:"defaultCharacterDescription,omitempty"` // Optional.
+	DefaultCharacterDescription CharacterDescription `json:"defaultCharacterDescription"` // Optional.
 	// DefaultCharacterAssets provides structured list of different assets
 	// associated with the character.
-	DefaultCharacterAssets CharacterAssets `json:"defaultCharacterAssets,omitempty"` // Optional.
+	DefaultCharacterAssets CharacterAssets `json:"defaultCharacterAssets"` // Optional.
 	// InitialMood defines the character's initial mood.
-	InitialMood CharacterInitialMood `json:"initialMood,omitempty"` // Optional.
+	InitialMood CharacterInitialMood `json:"initialMood"` // Optional.
 	// Personality provides structured description of the character's personality.
-	Personality CharacterPersonality `json:"personality,omitempty"` // Optional.
+	Personality CharacterPersonality `json:"personality"` // Optional.
 	// CommonKnowledge is the list of assigned common knowledge references.
 	CommonKnowledge []string `json:"commonKnowledge"` // Optional.
 	// SocialRank is the character's social rank - the insecure/confident slider’s
@@ -242,13 +405,14 @@ type Character struct {
 	// SharePortalInfo is an immutable field that contains character portal/arcade
 	// info. This field can't be set or changed via API.
 	// There is no documentation for the field.
-	SharePortalInfo any `json:"sharePortalInfo"` // Optional.
-	// YamlConfig used for defining goals and actions v2.
+	SharePortalInfo *SharePortalInfo `json:"sharePortalInfo"` // Optional.
+	// YamlConfig used for defining goals and actions v2. See GoalsConfig to
+	// build it programmatically.
 	YamlConfig string `json:"yamlConfig"` // Optional.
 	// SafetyConfig represents a list of safety configs.
 	SafetyConfig SafetyConfigEntry `json:"safetyConfig,omitempty"` // Optional.
 	// Relationship describes the character's relationships.
-	Relationship Relationship `json:"relationship,omitempty"` // Optional.
+	Relationship Relationship `json:"relationship"` // Optional.
 	// CognitiveControl describes the degree of cognitive control.
 	CognitiveControl CognitiveControl `json:"cognitiveControl,omitempty"` // Optional.
 
@@ -259,14 +423,35 @@ type Character struct {
 	// There is no documentation for this field. Apparently this is an enum, one of
 	// the values is FOURTH_WALL_CONTAINMENT_DISABLED.
 	FourthWall string `json:"fourthWall,omitempty"`
+	// Tags assigned by Inworld. This field is output only.
 	// There is no documentation for this field.
-	InworldTags []any `json:"inworldTags"`
+	InworldTags []Tag `json:"inworldTags"`
+	// Tags assigned by the user, see Character.AddUserTags and
+	// Character.RemoveUserTags.
 	// There is no documentation for this field.
-	UserTags []any `json:"userTags"`
+	UserTags []Tag `json:"userTags"`
 	// There is no documentation for this field.
-	LongTermCoherence struct {
-		Enabled bool `json:"enabled"`
-	} `json:"longTermCoherence,omitempty"`
+	LongTermCoherence LongTermCoherence `json:"longTermCoherence"`
+}
+
+// Validate checks the limits of the character documented by the API, see
+// PersonalKnowledge.Validate and CustomDialogStyle.Validate. CreateCharacter
+// and UpdateCharacter call it before sending the request, the methods
+// updating a part of the character validate only that part.
+func (c Character) Validate() error {
+	if c.PersonalKnowledge != nil {
+		if err := c.PersonalKnowledge.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i, s := range c.DefaultCharacterDescription.CustomDialogStyles {
+		if err := s.Validate(); err != nil {
+			return errors.Wrapf(err, "custom dialog style %d", i)
+		}
+	}
+
+	return nil
 }
 
 // CharacterAssets holds various assets associated with the character.
@@ -274,7 +459,7 @@ type Character struct {
 type CharacterAssets struct {
 	// Voice is a reference to the voice resource used by that character.
 	// There is no documentation for the field.
-	Voice Voice `json:"voice,omitempty"` // Optional.
+	Voice Voice `json:"voice"` // Optional.
 	// AvatarImg is a link to the uploaded and resized avatar image for the character provided by the user.
 	AvatarImg string `json:"avatarImg"` // Optional.
 	// AvatarImgOriginal is a link to the uploaded original avatar image for the character provided by the user.
@@ -284,9 +469,9 @@ type CharacterAssets struct {
 	// AvatarDisplayImageSource specifies the source from which the 2D avatar image of the character is derived.
 	AvatarDisplayImageSource AvatarDisplayImageSource `json:"avatarDisplayImageSource,omitempty"` // Optional.
 	// RPMAvatar represents the RPM avatar of the character.
-	RPMAvatar RPMAvatar `json:"rpmAvatar,omitempty"` // Optional.
+	RPMAvatar RPMAvatar `json:"rpmAvatar"` // Optional.
 	// InnequinAvatar represents the Innequin avatar of the character.
-	InnequinAvatar InnequinAvatar `json:"innequinAvatar,omitempty"` // Optional.
+	InnequinAvatar InnequinAvatar `json:"innequinAvatar"` // Optional.
 }
 
 // CharacterDescription describes a character with various properties.
@@ -302,10 +487,10 @@ type CharacterDescription struct {
 	// List of commonly used alternative names of this character.
 	Nicknames []string `json:"nicknames"` // Optional.
 	// Motivation of the character.
-	Motivation string `json:"motivation" json:"motivation,omitempty"` // Optional.
+	Motivation string `json:"motivation"` // Optional.
 	// URI to wikipedia for well-known character for additional data extraction. For
 	// more details: https://docs.inworld.ai/docs/tutorial-basics/identity/
-	WikipediaURI string `json:"wikipediaUri" json:"wikipediaURI,omitempty"` // Optional.
+	WikipediaURI string `json:"wikipediaUri"` // Optional.
 	// Example of character dialog. For more details:
 	// https://docs.inworld.ai/docs/tutorial-basics/dialog-style/#example-dialogue
 	ExampleDialog string `json:"exampleDialog"` // Optional.
@@ -347,6 +532,24 @@ type CharacterInitialMood struct {
 	Surprise int32 `json:"surprise"` // Optional.
 }
 
+// Validate checks that all mood sliders are in the range [-100, 100].
+func (m CharacterInitialMood) Validate() error {
+	for _, s := range []struct {
+		name  string
+		value int32
+	}{
+		{"joy", m.Joy},
+		{"fear", m.Fear},
+		{"trust", m.Trust},
+		{"surprise", m.Surprise},
+	} {
+		if s.value < -100 || s.value > 100 {
+			return errors.Errorf("initial mood %s must be in range [-100, 100], got %d", s.name, s.value)
+		}
+	}
+	return nil
+}
+
 // CharacterPersonality describes the personality of a character.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#characterpersonality
 type CharacterPersonality struct {
@@ -379,6 +582,14 @@ type CustomDialogStyle struct {
 	Colloquialism string `json:"colloquialism"` // Optional.
 }
 
+// Validate checks the limits of the custom dialog style documented by the API.
+func (s CustomDialogStyle) Validate() error {
+	if len(s.Adjectives) > 3 {
+		return errors.Errorf("custom dialog style can have up to 3 adjectives, got %d", len(s.Adjectives))
+	}
+	return nil
+}
+
 // Fact describes a fact.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#fact
 type Fact struct {
@@ -411,6 +622,63 @@ type InteractionCountStat struct {
 	TotalReadCount int32 `json:"totalReadCount,omitempty"` // Optional.
 }
 
+// LongTermCoherence configures the long-term coherence of the character's
+// conversation.
+// There is no documentation for this object.
+type LongTermCoherence struct {
+	// Enables long-term coherence.
+	Enabled bool `json:"enabled"`
+}
+
+// SharePortalInfo describes the sharing of the character on the Inworld
+// portal (arcade), nil while the character is not shared.
+// There is no documentation for this object and no real payload was available
+// when it was written, so the names and types of the typed fields are guesses
+// that are not verified. Fields that are missing or have another type are left
+// empty instead of failing the decoding of the character, the object is
+// always available as received in Raw. The package has no method to share a
+// character, since the API doesn't document one.
+type SharePortalInfo struct {
+	// Link to the character on the portal, to be embedded or shared.
+	PortalURL string `json:"portalUrl,omitempty"`
+	// Link to the character in the arcade.
+	ArcadeURL string `json:"arcadeUrl,omitempty"`
+	// Whether the character is listed publicly.
+	IsPublic bool `json:"isPublic,omitempty"`
+
+	// The object as returned by the API.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *SharePortalInfo) UnmarshalJSON(b []byte) error {
+	*i = SharePortalInfo{Raw: append(json.RawMessage(nil), b...)}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(b, &fields) != nil {
+		return nil
+	}
+
+	// Errors are ignored, the fields are unverified, see SharePortalInfo.
+	_ = json.Unmarshal(fields["portalUrl"], &i.PortalURL)
+	_ = json.Unmarshal(fields["arcadeUrl"], &i.ArcadeURL)
+	_ = json.Unmarshal(fields["isPublic"], &i.IsPublic)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The object is encoded as it was
+// received, if it was.
+func (i SharePortalInfo) MarshalJSON() ([]byte, error) {
+	if i.Raw != nil {
+		return i.Raw, nil
+	}
+
+	type info SharePortalInfo
+	b, err := json.Marshal(info(i))
+	return b, errors.Wrap(err, "json marshaling share portal info")
+}
+
 // Meta describes the statistics of the character.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/scenes/#meta
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#meta
@@ -439,6 +707,31 @@ type PersonalKnowledge struct {
 	Facts []Fact `json:"facts,omitempty"` // Optional.
 }
 
+// Limits of PersonalKnowledge documented by the API.
+const (
+	MaxPersonalKnowledgeFacts = 10000
+	MaxFactLength             = 255 // In runes.
+)
+
+// Validate checks the number of facts and the length of each fact.
+func (k PersonalKnowledge) Validate() error {
+	if len(k.Facts) > MaxPersonalKnowledgeFacts {
+		return errors.Errorf(
+			"personal knowledge can have up to %d facts, got %d",
+			MaxPersonalKnowledgeFacts,
+			len(k.Facts),
+		)
+	}
+
+	for i, f := range k.Facts {
+		if n := utf8.RuneCountInString(f.Text); n > MaxFactLength {
+			return errors.Errorf("fact %d is longer than %d characters: %d", i, MaxFactLength, n)
+		}
+	}
+
+	return nil
+}
+
 // Relationship describes the character's relationship configuration.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#relationship
 type Relationship struct {
@@ -466,7 +759,8 @@ type RPMAvatar struct {
 	RPMImageURIPosture string `json:"rpmImageUriPosture"` // Optional.
 }
 
-// SafetyConfigEntry represents a safety configuration entry.
+// SafetyConfigEntry represents a safety configuration entry. Keys are the
+// SafetyTopic constants.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/characters/#safetyconfigentry
 //
 // Examples of map keys:
@@ -730,10 +1024,10 @@ type StudioBaseVoice struct {
 	// Voice name.
 	Name string `json:"name,omitempty"` // Optional.
 	// TTS type.
-	TTSType TTSType `json:"ttsType,"` // Optional.
+	TTSType TTSType `json:"ttsType"` // Optional.
 	// TTS platform metadata - tts_metadata type must be compatible with the
 	// tts_type specified (enforced by service)
-	TtsMetadata ElevenLabsMetadata `json:"ttsMetadata,"` // Optional.
+	TtsMetadata ElevenLabsMetadata `json:"ttsMetadata"` // Optional.
 
 	// Voice gender.
 	// There is no documentation for this field.
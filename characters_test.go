@@ -0,0 +1,88 @@
+package inworld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMeta(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("view"); got != string(CharacterItemViewWithMeta) {
+			t.Errorf("got view %q, want %q", got, CharacterItemViewWithMeta)
+		}
+		_, _ = w.Write([]byte(`{
+			"name": "workspaces/w/characters/c",
+			"defaultCharacterDescription": {"givenName": "Server", "description": "changed on the server"},
+			"meta": {"totalCommonKnowledge": 2, "goalsVersion": 3, "totalTriggers": 4}
+		}`))
+	})
+
+	ch := Character{
+		Name: "workspaces/w/characters/c",
+		DefaultCharacterDescription: CharacterDescription{
+			GivenName:   "Local",
+			Description: "edited locally",
+		},
+	}
+	want := ch
+	want.Meta = &Meta{TotalCommonKnowledge: 2, GoalsVersion: 3, TotalTriggers: 4}
+
+	if err := c.LoadMeta(context.Background(), &ch); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ch, want) {
+		t.Errorf("got %+v, want %+v", ch, want)
+	}
+}
+
+func TestSharePortalInfo(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		info string
+		want SharePortalInfo
+	}{
+		{
+			name: "guessed fields",
+			info: `{"portalUrl":"https://example.com/p","arcadeUrl":"https://example.com/a","isPublic":true}`,
+			want: SharePortalInfo{PortalURL: "https://example.com/p", ArcadeURL: "https://example.com/a", IsPublic: true},
+		},
+		{
+			name: "other types",
+			info: `{"portalUrl":{"url":"https://example.com/p"},"isPublic":"yes","shareId":7}`,
+		},
+		{name: "not an object", info: `"https://example.com/p"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"name":"workspaces/w/characters/c","sharePortalInfo":` + tt.info + `}`))
+			})
+
+			ch, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := ch.SharePortalInfo
+			if got == nil {
+				t.Fatal("share portal info is nil")
+			}
+			if string(got.Raw) != tt.info {
+				t.Errorf("raw is %s, want %s", got.Raw, tt.info)
+			}
+			if got.PortalURL != tt.want.PortalURL || got.ArcadeURL != tt.want.ArcadeURL || got.IsPublic != tt.want.IsPublic {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.info {
+				t.Errorf("encoded as %s, want %s", b, tt.info)
+			}
+		})
+	}
+}
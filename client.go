@@ -3,16 +3,25 @@ package inworld
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	stderrors "errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -28,23 +37,52 @@ type Error struct {
 // Error implements error.
 func (e *Error) Error() string { return e.Message }
 
+// Is reports whether the target is an *Error with the same code. It makes
+// errors.Is work with the sentinel errors like ErrNotFound.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Sentinel errors to check the code of an *Error with errors.Is:
+//
+//	if errors.Is(err, inworld.ErrNotFound) {
+//		...
+//	}
+var (
+	ErrNotFound          error = &Error{Code: codes.NotFound, Message: "not found"}
+	ErrAlreadyExists     error = &Error{Code: codes.AlreadyExists, Message: "already exists"}
+	ErrPermissionDenied  error = &Error{Code: codes.PermissionDenied, Message: "permission denied"}
+	ErrUnauthenticated   error = &Error{Code: codes.Unauthenticated, Message: "unauthenticated"}
+	ErrInvalidArgument   error = &Error{Code: codes.InvalidArgument, Message: "invalid argument"}
+	ErrResourceExhausted error = &Error{Code: codes.ResourceExhausted, Message: "resource exhausted"}
+	ErrUnavailable       error = &Error{Code: codes.Unavailable, Message: "unavailable"}
+)
+
+// GRPCStatus makes status.FromError work with the error. Details tagged with
+// the "@type" of a google.rpc message (BadRequest, QuotaFailure, etc.) are
+// decoded into that message, others are kept as structpb.Value. Details that
+// can't be packed are replaced with errdetails.DebugInfo describing why, so
+// their loss is visible.
 func (e *Error) GRPCStatus() *status.Status {
 	s := status.New(e.Code, e.Message)
 	if len(e.Details) == 0 {
 		return s
 	}
 
-	details, err := structpb.NewList(e.Details)
-	if err != nil {
-		return s
-	}
-
-	d := make([]proto.Message, len(details.GetValues()))
-	for i, v := range details.GetValues() {
-		d[i] = v
+	d := make([]proto.Message, len(e.Details))
+	for i, v := range e.Details {
+		m, err := detailMessage(v)
+		if err != nil {
+			m = &errdetails.DebugInfo{Detail: fmt.Sprintf("detail %d can't be packed: %v", i, err)}
+		}
+		d[i] = m
 	}
 
 	detailed, err := s.WithDetails(d...)
+	if err != nil {
+		detailed, err = s.WithDetails(&errdetails.DebugInfo{Detail: "details can't be packed: " + err.Error()})
+	}
 	if err != nil {
 		return s
 	}
@@ -52,21 +90,144 @@ func (e *Error) GRPCStatus() *status.Status {
 	return detailed
 }
 
+// detailMessage decodes the detail of the error into the registered message
+// named by its "@type", falling back to structpb.Value.
+func detailMessage(detail interface{}) (proto.Message, error) {
+	if b, err := json.Marshal(detail); err == nil {
+		var a anypb.Any
+		if protojson.Unmarshal(b, &a) == nil {
+			if m, err := a.UnmarshalNew(); err == nil {
+				if m, ok := m.(proto.Message); ok {
+					return m, nil
+				}
+			}
+		}
+	}
+
+	v, err := structpb.NewValue(detail)
+	return v, errors.WithStack(err)
+}
+
 // NewClient creates a new instance of the Client struct and initializes its
 // fields with the provided values. It takes in two API keys (simpleAPIKey and
-// studioAPIKey) as strings, and an http client.
-func NewClient(simpleAPIKey, studioAPIKey string, client http.Client) Client {
-	return Client{
+// studioAPIKey) as strings, an http client and optional settings. The keys are
+// sent as is with the Basic scheme, so they must be Base64-encoded "key:secret"
+// pairs as copied from Studio, see WithStudioBasicCredentials for the raw
+// ones. The http client is copied once and shared by all copies of the
+// returned Client. See NewClientFromConfig to configure it from a file.
+func NewClient(simpleAPIKey, studioAPIKey string, client http.Client, opts ...Option) Client {
+	c := Client{
 		simpleAPIKey: simpleAPIKey,
 		studioAPIKey: studioAPIKey,
-		client:       client,
+		client:       &client,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	c.retry = newRetryPolicy(c)
+
+	// Middleware is applied after all options, so it wraps the transport
+	// configured by them regardless of the order of options.
+	if len(c.middleware) > 0 {
+		rt := c.client.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+
+		for i := len(c.middleware) - 1; i >= 0; i-- {
+			rt = c.middleware[i](rt)
+		}
+
+		cl := *c.client
+		cl.Transport = rt
+		c.client = &cl
+	}
+
+	return c
+}
+
+// DefaultTimeout is the timeout of the http client used by Default and
+// FromEnv.
+const DefaultTimeout = time.Minute
+
+// Default creates a client for quick scripts using the default transport and
+// DefaultTimeout. Any of the keys may be empty if the corresponding API is not
+// used.
+func Default(simpleAPIKey, studioAPIKey string) Client {
+	return NewClient(simpleAPIKey, studioAPIKey, http.Client{Timeout: DefaultTimeout})
+}
+
+// Environment variables read by FromEnv.
+const (
+	EnvSimpleAPIKey = "INWORLD_SIMPLE_API_KEY"
+	EnvStudioAPIKey = "INWORLD_STUDIO_API_KEY"
+)
+
+// FromEnv creates a client like Default does, reading the keys from the
+// INWORLD_SIMPLE_API_KEY and INWORLD_STUDIO_API_KEY environment variables. At
+// least one of them must be set.
+func FromEnv() (Client, error) {
+	simpleAPIKey, studioAPIKey := os.Getenv(EnvSimpleAPIKey), os.Getenv(EnvStudioAPIKey)
+	if simpleAPIKey == "" && studioAPIKey == "" {
+		return Client{}, errors.Errorf("neither %s nor %s is set", EnvSimpleAPIKey, EnvStudioAPIKey)
 	}
+
+	return Default(simpleAPIKey, studioAPIKey), nil
 }
 
+// Client provides access to the simple and studio APIs. Client holds only
+// immutable values and pointers to shared dependencies, so it is cheap to copy
+// and a single Client (or any of its copies) is safe for concurrent use by
+// multiple goroutines.
 type Client struct {
 	simpleAPIKey string
 	studioAPIKey string
-	client       http.Client
+	client       *http.Client
+
+	maxResponseBytes  int64
+	maxErrorBodyBytes int64
+	requestTimeout    time.Duration
+	maxRetries        int
+	retryBudget       *retryBudget
+	retryable         func(*http.Response, error) bool
+	retry             *retryPolicy
+	clock             Clock
+	baseURL           *url.URL
+	middleware        []func(http.RoundTripper) http.RoundTripper
+	signer            func(*http.Request) error
+	defaultWorkspace  string
+	strictPageSize    bool
+	studioAuth        credentials
+	simpleAuth        credentials
+
+	validateYamlConfig bool
+	metadataHeaders    metadataHeaders
+}
+
+// The REST API of Inworld is served by a gRPC gateway, which forwards headers
+// with the Grpc-Metadata- prefix to the backend as gRPC metadata. The backend
+// uses it to tell studio API keys from simple API keys and to bind requests to
+// an opened session.
+const (
+	defaultBearerTypeHeader = "Grpc-Metadata-X-Authorization-Bearer-Type"
+	defaultSessionIDHeader  = "Grpc-Metadata-Session-Id"
+)
+
+// metadataHeaders holds names of the headers set by the client, zero value
+// means the default names.
+type metadataHeaders struct {
+	custom     bool
+	bearerType string
+	sessionID  string
+}
+
+func (h metadataHeaders) names() (bearerType, sessionID string) {
+	if !h.custom {
+		return defaultBearerTypeHeader, defaultSessionIDHeader
+	}
+	return h.bearerType, h.sessionID
 }
 
 var (
@@ -83,51 +244,262 @@ var (
 )
 
 func sendStudioAPIRequest[T any](c Client, r *http.Request) (T, error) {
-	r.Header.Set("Authorization", "Basic "+c.studioAPIKey)
-	r.Header.Set("Grpc-Metadata-X-Authorization-Bearer-Type", "studio_api")
+	if err := authorize(r, c.studioAuth, c.studioAPIKey, c.getClock()); err != nil {
+		var zero T
+		return zero, err
+	}
+	if h, _ := c.metadataHeaders.names(); h != "" {
+		r.Header.Set(h, "studio_api")
+	}
 	return sendRequest[T](c, r)
 }
 
 func sendSimpleAPIRequest[T any](c Client, r *http.Request, sessionID string) (T, error) {
-	r.Header.Set("Authorization", "Basic "+c.simpleAPIKey)
-	if sessionID != "" {
-		r.Header.Set("Grpc-Metadata-Session-Id", sessionID)
+	if err := authorize(r, c.simpleAuth, c.simpleAPIKey, c.getClock()); err != nil {
+		var zero T
+		return zero, err
+	}
+	if _, h := c.metadataHeaders.names(); h != "" && sessionID != "" {
+		r.Header.Set(h, sessionID)
 	}
 	return sendRequest[T](c, r)
 }
 
-func sendRequest[T any](c Client, r *http.Request) (response T, err error) {
-	resp, err := c.client.Do(r)
+func sendRequest[T any](c Client, r *http.Request) (T, error) {
+	c.rebase(r)
+	c.setMetadata(r)
+	r.Header.Set("Accept", "application/json")
+	if r.Body != nil && r.Body != http.NoBody && r.Header.Get("Content-Type") == "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.signer != nil {
+		if err := bufferBody(r); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+
+	if c.retry == nil {
+		response, _, err := doRequest[T](c, r)
+		return response, err
+	}
+
+	return retryRequest(c, r, doRequest[T])
+}
+
+// rebase points the request built against https://api.inworld.ai to the base
+// URL set with WithBaseURL. Escaped segments of both paths are kept as is.
+func (c Client) rebase(r *http.Request) {
+	if c.baseURL == nil {
+		return
+	}
+
+	u := *r.URL
+	u.Scheme, u.Host = c.baseURL.Scheme, c.baseURL.Host
+	u.Path = strings.TrimSuffix(c.baseURL.Path, "/") + r.URL.Path
+	u.RawPath = ""
+	if r.URL.RawPath != "" || c.baseURL.RawPath != "" {
+		u.RawPath = strings.TrimSuffix(c.baseURL.EscapedPath(), "/") + r.URL.EscapedPath()
+	}
+
+	r.URL, r.Host = &u, u.Host
+}
+
+// doRequest makes a single attempt of sending the request. The http response
+// is returned with the closed body, nil if it wasn't received.
+func doRequest[T any](c Client, r *http.Request) (response T, httpResp *http.Response, err error) {
+	if c.requestTimeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), c.requestTimeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+
+	if c.signer != nil {
+		if err := c.signer(r); err != nil {
+			return response, httpResp, errors.Wrap(err, "signing request")
+		}
+	}
+
+	client := c.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(r)
 	if err != nil {
-		return response, errors.WithStack(err)
+		return response, nil, errors.WithStack(err)
 	}
+	httpResp = resp
 
 	defer func() { err = combine(err, errors.WithStack(resp.Body.Close())) }()
 
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return response, errors.Wrap(err, "reading http body")
+	var body io.Reader = resp.Body
+
+	// The transport decompresses the body only if it has requested the
+	// compression itself, so it is done here when the Accept-Encoding header
+	// was set by a middleware or a proxy compresses responses anyway.
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") && !resp.Uncompressed {
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return response, httpResp, errors.Wrap(err, "reading gzip body")
+		}
+		defer zr.Close()
+		body = zr
 	}
 
-	rdr := json.NewDecoder(bytes.NewReader(b))
+	if c.maxResponseBytes > 0 {
+		body = &limitedReader{r: body, n: c.maxResponseBytes}
+	}
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		// The body is buffered only in case of errors to include it in the
+		// error message if it can't be decoded. Error bodies are always bounded,
+		// a truncated one is reported like any other malformed body.
+		errorBodyLimit := c.maxErrorBodyBytes
+		if errorBodyLimit <= 0 {
+			errorBodyLimit = DefaultMaxErrorBodyBytes
+		}
+
+		b, err := io.ReadAll(io.LimitReader(body, errorBodyLimit))
+		if err != nil {
+			return response, httpResp, errors.Wrap(err, "reading http body")
+		}
+
+		// Some errors (e.g. the ones returned by proxies) have no code or are
+		// not JSON at all, the code is derived from the status then.
 		var e Error
-		if err = rdr.Decode(&e); err != nil || e.Code == codes.OK {
-			return response, errors.Errorf(
-				"request failed with status %d: %s",
-				resp.StatusCode,
-				limit(b, 200),
-			)
+		if err = json.Unmarshal(b, &e); err != nil || e.Code == codes.OK {
+			e.Code = codeFromHTTPStatus(resp.StatusCode)
+		}
+		if e.Message == "" {
+			e.Message = fmt.Sprintf("request failed with status %d: %s", resp.StatusCode, limit(b, 200))
 		}
-		return response, errors.WithStack(&e)
+		return response, httpResp, errors.WithStack(&e)
+	}
+
+	// Errors reported in the body of a 200 OK response are detected only for
+	// the custom classifier of retries, see WithRetryableFunc.
+	if c.retry != nil && c.retry.retryable != nil {
+		return decodeReportingError[T](body, httpResp)
+	}
+
+	err = json.NewDecoder(body).Decode(&response)
+
+	// Methods that don't expect any data in response (e.g. deletions) must not
+	// fail when the server replies with an empty body, like 204 No Content.
+	if _, ok := any(response).(struct{}); ok && stderrors.Is(err, io.EOF) {
+		return response, httpResp, nil
+	}
+
+	if err != nil {
+		return response, httpResp, errors.Wrapf(err, "json unmarshaling to %T", response)
+	}
+
+	return response, httpResp, nil
+}
+
+// statusOKError is an error reported in the body of a 200 OK response. It is
+// passed only to the custom classifier of retries, the decoded response is
+// returned to the caller unless the request is retried.
+type statusOKError struct{ err *Error }
+
+func (e *statusOKError) Error() string { return e.err.Error() }
+
+// decodeReportingError decodes the successful response like doRequest and
+// also reports the error found in its body. The body is buffered to be
+// decoded twice. None of the API responses has the top-level "code" field, so
+// the body is an error if it has a non-zero code and a message.
+func decodeReportingError[T any](body io.Reader, httpResp *http.Response) (response T, _ *http.Response, err error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return response, httpResp, errors.Wrap(err, "reading http body")
+	}
+
+	if _, ok := any(response).(struct{}); ok && len(bytes.TrimSpace(b)) == 0 {
+		return response, httpResp, nil
+	}
+
+	if err = json.Unmarshal(b, &response); err != nil {
+		return response, httpResp, errors.Wrapf(err, "json unmarshaling to %T", response)
+	}
+
+	var e Error
+	if json.Unmarshal(b, &e) == nil && e.Code != codes.OK && e.Message != "" {
+		return response, httpResp, &statusOKError{err: &e}
+	}
+
+	return response, httpResp, nil
+}
+
+// codeFromHTTPStatus maps the http status to the gRPC code the same way the
+// gRPC gateway maps codes to statuses.
+func codeFromHTTPStatus(status int) codes.Code {
+	switch status {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499: // Client Closed Request.
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// DefaultMaxErrorBodyBytes is the default limit of the error response body
+// size, see WithMaxErrorBodyBytes.
+const DefaultMaxErrorBodyBytes = 256 << 10
+
+// ErrResponseTooLarge is returned when the response body exceeds the limit set
+// by WithMaxResponseBytes.
+var ErrResponseTooLarge = stderrors.New("response body is too large")
+
+// limitedReader is like io.LimitedReader, but returns ErrResponseTooLarge
+// instead of io.EOF when the limit is exceeded.
+type limitedReader struct {
+	r io.Reader
+	n int64 // Bytes remaining.
+}
+
+func (l *limitedReader) Read(p []byte) (n int, err error) {
+	if l.n < 0 {
+		return 0, errors.WithStack(ErrResponseTooLarge)
+	}
+
+	// Read one byte more than allowed to tell the body of exactly the limit
+	// size from the oversized one.
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
 	}
 
-	if err = rdr.Decode(&response); err != nil {
-		return response, errors.Wrapf(err, "json unmarshaling to %T: %s", response, limit(b, 200))
+	n, err = l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		// The extra byte is dropped, otherwise a decoder could complete a
+		// value with it and ignore the error.
+		return n + int(l.n), errors.WithStack(ErrResponseTooLarge)
 	}
 
-	return response, nil
+	return n, err
 }
 
 func limit(v []byte, limit int) []byte {
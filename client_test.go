@@ -0,0 +1,392 @@
+package inworld
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// newTestClient returns a client sending all requests to the handler.
+func newTestClient(t *testing.T, h http.HandlerFunc, opts ...Option) Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewClient("simple-key", "studio-key", *srv.Client(), append([]Option{WithBaseURL(u)}, opts...)...)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// noSleepClock doesn't wait, so retries and polling are instant in tests.
+type noSleepClock struct{}
+
+func (noSleepClock) Now() time.Time { return time.Now() }
+
+func (noSleepClock) Sleep(ctx context.Context, _ time.Duration) error { return ctx.Err() }
+
+// TestClientConcurrentUse is meant to be run with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	var requests, refreshes, sent atomic.Int64
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer token-") {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		if requests.Add(1)%3 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	},
+		WithRetries(2),
+		WithRetryBudget(0.5),
+		WithClock(noSleepClock{}),
+		WithStudioCredentials(AuthSchemeBearer, credentialSourceFunc(func(context.Context) (string, time.Time, error) {
+			// Credentials expire right away, so they are refreshed concurrently.
+			return "token-" + strconv.FormatInt(refreshes.Add(1), 10), time.Now().Add(DefaultExpiryMargin), nil
+		})),
+		WithTransportMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				sent.Add(1)
+				return next.RoundTrip(r)
+			})
+		}),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+				if err != nil && !errors.Is(err, ErrUnavailable) {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sent.Load() != requests.Load() {
+		t.Errorf("middleware saw %d requests, server %d", sent.Load(), requests.Load())
+	}
+}
+
+func TestDeleteWithEmptyBody(t *testing.T) {
+	deletes := map[string]func(Client) error{
+		"character": func(c Client) error {
+			return c.DeleteCharacter(context.Background(), "workspaces/w/characters/c")
+		},
+		"scene": func(c Client) error {
+			return c.DeleteScene(context.Background(), "workspaces/w/scenes/s")
+		},
+		"common knowledge": func(c Client) error {
+			return c.DeleteCommonKnowledge(context.Background(), "workspaces/w/common-knowledge/k")
+		},
+	}
+
+	for _, status := range []int{http.StatusNoContent, http.StatusOK} {
+		for name, del := range deletes {
+			t.Run(name+" "+strconv.Itoa(status), func(t *testing.T) {
+				c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+					if r.Method != http.MethodDelete {
+						t.Errorf("method is %s, want DELETE", r.Method)
+					}
+					w.WriteHeader(status)
+				})
+
+				if err := del(c); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	}
+}
+
+func TestOversizedErrorBody(t *testing.T) {
+	const bodySize = 64 << 20
+
+	for _, compressed := range []bool{false, true} {
+		t.Run("gzip "+strconv.FormatBool(compressed), func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				var body io.Writer = w
+				if compressed {
+					w.Header().Set("Content-Encoding", "gzip")
+					zw := gzip.NewWriter(w)
+					defer zw.Close()
+					body = zw
+				}
+				w.WriteHeader(http.StatusNotFound)
+
+				// Any prefix of the body is malformed JSON.
+				_, _ = io.WriteString(body, `{"message":"`)
+				_, _ = io.CopyN(body, zeros{}, bodySize)
+			},
+				WithMaxErrorBodyBytes(1<<10),
+				// The transport decompresses the body itself unless the
+				// encoding is requested explicitly.
+				WithTransportMiddleware(func(next http.RoundTripper) http.RoundTripper {
+					return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+						r.Header.Set("Accept-Encoding", "gzip")
+						return next.RoundTrip(r)
+					})
+				}),
+			)
+
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			_, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+			runtime.ReadMemStats(&after)
+
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("error is %v, want ErrNotFound", err)
+			}
+			if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 4<<20 {
+				t.Errorf("%d bytes allocated reading the error", allocated)
+			}
+		})
+	}
+}
+
+type zeros struct{}
+
+func (zeros) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = '0'
+	}
+	return len(b), nil
+}
+
+func TestStatusOnlyErrorBodies(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		code     codes.Code
+		sentinel error
+	}{
+		{status: http.StatusBadRequest, code: codes.InvalidArgument, sentinel: ErrInvalidArgument},
+		{status: http.StatusUnauthorized, code: codes.Unauthenticated, sentinel: ErrUnauthenticated},
+		{status: http.StatusForbidden, code: codes.PermissionDenied, sentinel: ErrPermissionDenied},
+		{status: http.StatusNotFound, code: codes.NotFound, sentinel: ErrNotFound},
+		{status: http.StatusNotFound, body: "<html>404 Not Found</html>", code: codes.NotFound, sentinel: ErrNotFound},
+		{status: http.StatusNotFound, body: `{"message":"no such character"}`, code: codes.NotFound, sentinel: ErrNotFound},
+		{status: http.StatusConflict, code: codes.AlreadyExists, sentinel: ErrAlreadyExists},
+		{status: http.StatusPreconditionFailed, code: codes.FailedPrecondition},
+		{status: http.StatusRequestEntityTooLarge, code: codes.ResourceExhausted, sentinel: ErrResourceExhausted},
+		{status: http.StatusTooManyRequests, code: codes.ResourceExhausted, sentinel: ErrResourceExhausted},
+		{status: 499, code: codes.Canceled},
+		{status: http.StatusInternalServerError, code: codes.Internal},
+		{status: http.StatusNotImplemented, code: codes.Unimplemented},
+		{status: http.StatusBadGateway, body: "bad gateway", code: codes.Unavailable, sentinel: ErrUnavailable},
+		{status: http.StatusServiceUnavailable, code: codes.Unavailable, sentinel: ErrUnavailable},
+		{status: http.StatusGatewayTimeout, code: codes.DeadlineExceeded},
+		{status: http.StatusTeapot, code: codes.Unknown},
+		// The code of the body wins over the status.
+		{status: http.StatusBadRequest, body: `{"code":5,"message":"not found"}`, code: codes.NotFound, sentinel: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status)+" "+tt.body, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			})
+
+			_, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("error %v is not *Error", err)
+			}
+			if e.Code != tt.code {
+				t.Errorf("code is %v, want %v", e.Code, tt.code)
+			}
+			if e.Message == "" {
+				t.Error("message is empty")
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("error %v is not %v", err, tt.sentinel)
+			}
+		})
+	}
+}
+
+func TestContentHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(Client) error
+		contentType string
+	}{
+		{
+			name: "get",
+			call: func(c Client) error {
+				_, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+				return err
+			},
+		},
+		{
+			name: "delete",
+			call: func(c Client) error {
+				return c.DeleteCharacter(context.Background(), "workspaces/w/characters/c")
+			},
+		},
+		{
+			name: "create",
+			call: func(c Client) error {
+				_, err := c.CreateCharacter(context.Background(), "w", Character{})
+				return err
+			},
+			contentType: "application/json",
+		},
+		{
+			name: "simple api",
+			call: func(c Client) error {
+				_, err := c.SendTrigger(context.Background(), SendTriggerRequest{
+					SessionID:        "s",
+					SessionCharacter: "workspaces/w/sessions/s/sessionCharacters/c",
+					TriggerEvent:     TriggerEvent{Trigger: "greet"},
+				})
+				return err
+			},
+			contentType: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept"); got != "application/json" {
+					t.Errorf("Accept is %q, want application/json", got)
+				}
+				if got := r.Header.Get("Content-Type"); got != tt.contentType {
+					t.Errorf("Content-Type is %q, want %q", got, tt.contentType)
+				}
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	const path = "/proxy/studio/v1/workspaces/a%2Fb/characters"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI != path {
+			t.Errorf("request uri is %q, want %q", r.RequestURI, path)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	base, err := url.Parse(srv.URL + "/proxy/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var signed string
+	c := NewClient("simple-key", "studio-key", *srv.Client(),
+		WithBaseURL(base),
+		WithRequestSigner(func(r *http.Request) error {
+			signed = r.Host + r.URL.RequestURI()
+			return nil
+		}),
+	)
+
+	if _, err = Do[struct{}](context.Background(), c, AuthStudio, http.MethodGet, "studio/v1/workspaces/a%2Fb/characters", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := base.Host + path; signed != want {
+		t.Errorf("signed %q, want %q", signed, want)
+	}
+}
+
+func TestResponseTooLarge(t *testing.T) {
+	const body = `{"name":"workspaces/w/characters/c"}`
+
+	for _, tt := range []struct {
+		name    string
+		status  int
+		body    string
+		limit   int64
+		wantErr error
+	}{
+		{name: "success within limit", status: http.StatusOK, body: body, limit: int64(len(body))},
+		{name: "success over limit", status: http.StatusOK, body: body, limit: int64(len(body)) - 1, wantErr: ErrResponseTooLarge},
+		{
+			name:    "error over limit",
+			status:  http.StatusNotFound,
+			body:    `{"code":5,"message":"character not found"}`,
+			limit:   10,
+			wantErr: ErrResponseTooLarge,
+		},
+		{
+			name:    "error within limit",
+			status:  http.StatusNotFound,
+			body:    `{"code":5,"message":"character not found"}`,
+			limit:   1 << 10,
+			wantErr: ErrNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}, WithMaxResponseBytes(tt.limit))
+
+			_, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+			if tt.wantErr == nil && err != nil || !errors.Is(err, tt.wantErr) {
+				t.Errorf("error is %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestLargeResponseIsStreamed checks that a successful body is decoded as it
+// is read: the decoding of an oversized list fails without reading it whole.
+func TestLargeResponseIsStreamed(t *testing.T) {
+	const bodySize = 64 << 20
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"characters":[{"name":"`)
+		_, _ = io.CopyN(w, zeros{}, bodySize)
+		_, _ = io.WriteString(w, `"}]}`)
+	}, WithMaxResponseBytes(1<<20))
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := c.GetCharacters(context.Background(), GetCharactersRequest{WorkspaceID: "w"})
+	runtime.ReadMemStats(&after)
+
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("error is %v, want ErrResponseTooLarge", err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 16<<20 {
+		t.Errorf("%d bytes allocated reading the response", allocated)
+	}
+}
@@ -0,0 +1,48 @@
+package inworld
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time used by the client for backoff of retries and
+// polling of long-running operations. The real time is used by default, a fake
+// clock may be set with WithClock to test the code using the client without
+// real waits.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep waits for d or until the context is canceled, in which case it
+	// returns the context error.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// WithClock sets the clock used by the client, see Clock.
+func WithClock(clock Clock) Option {
+	return func(c *Client) { c.clock = clock }
+}
+
+// realClock is the Clock using the real time.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// getClock returns the clock of the client, the real one if it's not set.
+func (c Client) getClock() Clock {
+	if c.clock == nil {
+		return realClock{}
+	}
+	return c.clock
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -19,21 +21,22 @@ func (c Client) CreateCommonKnowledge(
 	workspaceID string,
 	k CommonKnowledge,
 ) (CommonKnowledge, error) {
-	if workspaceID == "" {
-		return CommonKnowledge{}, errors.New("workspace id is required")
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return CommonKnowledge{}, err
 	}
 
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
 		apiStudioV1.JoinPath("workspaces", workspaceID, "common-knowledge").String(),
-		newReader(k),
+		newReader(k.forRequest()),
 	)
 	if err != nil {
 		return CommonKnowledge{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[CommonKnowledge](c, r)
+	return withValidationError(sendStudioAPIRequest[CommonKnowledge](c, r))
 }
 
 // GetCommonKnowledge to get a specific common knowledge entry within a
@@ -59,14 +62,14 @@ func (c Client) GetCommonKnowledge(ctx context.Context, commonKnowledgeID string
 
 // DeployCommonKnowledge asynchronously deploys common knowledge. The deployment
 // process is managed as a long-running operation (LRO). The progress and result
-// of this operation should be monitored using the returned LRO object. Upon
-// successful completion, all characters and scenes that incorporate this common
-// knowledge will reflect the updates during their interactions. In the event of
-// a failure, the operation should be retried.
+// of this operation should be monitored using the returned LRO object, see
+// PollOperation. Upon successful completion, all characters and scenes that
+// incorporate this common knowledge will reflect the updates during their
+// interactions. In the event of a failure, the operation should be retried.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/common-knowledge/#deploy-common-knowledge
-func (c Client) DeployCommonKnowledge(ctx context.Context, commonKnowledgeID string) (DeploymentResponse, error) {
+func (c Client) DeployCommonKnowledge(ctx context.Context, commonKnowledgeID string) (Operation, error) {
 	if commonKnowledgeID == "" {
-		return DeploymentResponse{}, errors.New("common knowledge id is required")
+		return Operation{}, errors.New("common knowledge id is required")
 	}
 
 	r, err := http.NewRequestWithContext(
@@ -76,10 +79,10 @@ func (c Client) DeployCommonKnowledge(ctx context.Context, commonKnowledgeID str
 		http.NoBody,
 	)
 	if err != nil {
-		return DeploymentResponse{}, errors.WithStack(err)
+		return Operation{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[DeploymentResponse](c, r)
+	return startOperation(c, r)
 }
 
 // ListCommonKnowledge returns a list of common knowledge that can be filtered
@@ -90,8 +93,20 @@ func (c Client) ListCommonKnowledge(
 	ctx context.Context,
 	req ListCommonKnowledgeRequest,
 ) (ListCommonKnowledgeResponse, error) {
-	if req.WorkspaceID == "" {
-		return ListCommonKnowledgeResponse{}, errors.New("workspace id is required")
+	workspaceID, err := c.workspace(req.WorkspaceID)
+	if err != nil {
+		return ListCommonKnowledgeResponse{}, err
+	}
+	req.WorkspaceID = workspaceID
+
+	if req.PageSize, err = c.pageSize(req.PageSize); err != nil {
+		return ListCommonKnowledgeResponse{}, err
+	}
+
+	fp := req.fingerprint()
+	pageToken, err := req.Cursor.token(fp, req.PageToken)
+	if err != nil {
+		return ListCommonKnowledgeResponse{}, errors.WithStack(err)
 	}
 
 	url := apiStudioV1.JoinPath("workspaces", req.WorkspaceID, "common-knowledge")
@@ -103,8 +118,8 @@ func (c Client) ListCommonKnowledge(
 	if req.PageSize > 0 {
 		q.Add("pageSize", strconv.FormatInt(int64(req.PageSize), 10))
 	}
-	if req.PageToken != "" {
-		q.Add("pageToken", req.PageToken)
+	if pageToken != "" {
+		q.Add("pageToken", pageToken)
 	}
 
 	url.RawQuery = q.Encode()
@@ -119,7 +134,9 @@ func (c Client) ListCommonKnowledge(
 		return ListCommonKnowledgeResponse{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[ListCommonKnowledgeResponse](c, r)
+	resp, err := sendStudioAPIRequest[ListCommonKnowledgeResponse](c, r)
+	resp.NextPageCursor = PageCursor{Token: resp.NextPageToken, fingerprint: fp}
+	return resp, err
 }
 
 // UpdateCommonKnowledge updates the specified common knowledge. Changes to
@@ -130,22 +147,64 @@ func (c Client) UpdateCommonKnowledge(
 	ctx context.Context,
 	commonKnowledgeID string,
 	k CommonKnowledge,
+) (CommonKnowledge, error) {
+	return c.patchCommonKnowledge(ctx, commonKnowledgeID, k)
+}
+
+// SetCommonKnowledgeDisplayName renames the common knowledge. Other fields of
+// the common knowledge are not changed.
+func (c Client) SetCommonKnowledgeDisplayName(
+	ctx context.Context,
+	commonKnowledgeID, displayName string,
+) (CommonKnowledge, error) {
+	return c.patchCommonKnowledge(ctx, commonKnowledgeID, CommonKnowledge{DisplayName: displayName}, "displayName")
+}
+
+// SetCommonKnowledgeDescription sets the description of the common knowledge.
+// Other fields of the common knowledge are not changed.
+func (c Client) SetCommonKnowledgeDescription(
+	ctx context.Context,
+	commonKnowledgeID, description string,
+) (CommonKnowledge, error) {
+	return c.patchCommonKnowledge(ctx, commonKnowledgeID, CommonKnowledge{Description: description}, "description")
+}
+
+// patchCommonKnowledge updates the common knowledge. When updateMask is not
+// empty, only the listed fields (JSON paths, e.g. "displayName") are changed.
+func (c Client) patchCommonKnowledge(
+	ctx context.Context,
+	commonKnowledgeID string,
+	k CommonKnowledge,
+	updateMask ...string,
 ) (CommonKnowledge, error) {
 	if commonKnowledgeID == "" {
 		return CommonKnowledge{}, errors.New("common knowledge id is required")
 	}
 
+	for _, path := range updateMask {
+		if path == "" {
+			return CommonKnowledge{}, errors.New("update mask path cannot be empty")
+		}
+	}
+
+	url := apiStudioV1.JoinPath(commonKnowledgeID)
+	if len(updateMask) > 0 {
+		q := url.Query()
+		q.Add("updateMask", strings.Join(updateMask, ","))
+		url.RawQuery = q.Encode()
+	}
+
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPatch,
-		apiStudioV1.JoinPath(commonKnowledgeID).String(),
-		newReader(k),
+		url.String(),
+		newReader(k.forRequest()),
 	)
 	if err != nil {
 		return CommonKnowledge{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[CommonKnowledge](c, r)
+	return withValidationError(sendStudioAPIRequest[CommonKnowledge](c, r))
 }
 
 // DeleteCommonKnowledge deletes a specific common knowledge entry within a
@@ -173,9 +232,9 @@ func (c Client) DeleteCommonKnowledge(ctx context.Context, commonKnowledgeID str
 // ListCommonKnowledgeRequest is a struct representing a request to list common knowledge items.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/common-knowledge/#request-body-1
 type ListCommonKnowledgeRequest struct {
-	WorkspaceID string // Required.
+	WorkspaceID string // Required, unless set with WithDefaultWorkspace.
 	// Max number of items to retrieve per page. Default is 50.
-	PageSize int32 // Optional.
+	PageSize int32 // Optional, see MaxPageSize.
 	// A page token, received from a previous ListCommonKnowledge call. Provide this
 	// to retrieve the subsequent page. When paginating, all other parameters
 	// provided to ListCommonKnowledgeRequest must stay the same.
@@ -190,6 +249,16 @@ type ListCommonKnowledgeRequest struct {
 	//  use common_knowledge.name=workspaces/{workspace}/common-knowledge/{uuid1} OR
 	//  common_knowledge.name=workspaces/{workspace}/common-knowledge/{uuid2}.
 	Filter string // Optional.
+	// A cursor received from a previous ListCommonKnowledge call. Takes
+	// precedence over PageToken, unlike the raw token ListCommonKnowledge
+	// returns ErrInconsistentPagination if other parameters have changed, and
+	// ErrNoMorePages for the cursor of the last page.
+	Cursor PageCursor // Optional.
+}
+
+func (req ListCommonKnowledgeRequest) fingerprint() string {
+	req.PageToken, req.Cursor = "", PageCursor{}
+	return fingerprint(req)
 }
 
 // ListCommonKnowledgeResponse is a struct representing the response from a list
@@ -198,6 +267,8 @@ type ListCommonKnowledgeRequest struct {
 type ListCommonKnowledgeResponse struct {
 	CommonKnowledge []CommonKnowledge `json:"commonKnowledge"`
 	NextPageToken   string            `json:"nextPageToken"`
+	// NextPageCursor is NextPageToken bound to the parameters of the request.
+	NextPageCursor PageCursor `json:"-"`
 }
 
 // CommonKnowledge represents a piece of knowledge in the system.
@@ -206,6 +277,11 @@ type CommonKnowledge struct {
 	// Name cannot be set or changed, this field is output only.
 	// Format workspaces/{workspaceID}/common-knowledge/{commonKnowledgeID}
 	Name string `json:"name,omitempty"`
+	// Time of the creation and of the last update, nil if not returned by the
+	// server. These fields can't be set or changed via API.
+	// There is no documentation for these fields.
+	CreateTime *time.Time `json:"createTime,omitempty"` // Optional.
+	UpdateTime *time.Time `json:"updateTime,omitempty"` // Optional.
 	// Display name of the common knowledge.
 	DisplayName string `json:"displayName,omitempty"` // Optional.
 	// Free form description outlining the character's behavior and purpose. See
@@ -216,6 +292,7 @@ type CommonKnowledge struct {
 	// is 10000.
 	MemoryRecords []string `json:"memoryRecords"` // Optional.
 
+	// Tags assigned by Inworld. This field is output only.
 	// There is no documentation for this field.
-	InworldTags []any `json:"inworldTags"`
+	InworldTags []Tag `json:"inworldTags"`
 }
@@ -0,0 +1,53 @@
+package inworld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestSetCommonKnowledgeDisplayName(t *testing.T) {
+	stored := CommonKnowledge{
+		Name:          "workspaces/w/common-knowledge/k",
+		DisplayName:   "old",
+		Description:   "facts about the town",
+		MemoryRecords: []string{"The mayor is a cat.", "The bridge is closed."},
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("method is %s, want PATCH", r.Method)
+		}
+		if got := r.URL.Query().Get("updateMask"); got != "displayName" {
+			t.Errorf("updateMask is %q, want displayName", got)
+		}
+
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		if records, ok := body["memoryRecords"]; ok && string(records) != "null" {
+			t.Errorf("memory records %s are sent", records)
+		}
+
+		// The server changes only the fields listed in the mask.
+		updated := stored
+		if err := json.Unmarshal(body["displayName"], &updated.DisplayName); err != nil {
+			t.Error(err)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(updated)
+	})
+
+	k, err := c.SetCommonKnowledgeDisplayName(context.Background(), stored.Name, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if k.DisplayName != "new" || k.Description != stored.Description || !slices.Equal(k.MemoryRecords, stored.MemoryRecords) {
+		t.Errorf("unexpected common knowledge %+v", k)
+	}
+}
@@ -0,0 +1,97 @@
+package inworld
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the client read by NewClientFromConfig from
+// JSON or YAML. Every field maps to an option of NewClient, which remains the
+// canonical way to configure the client.
+type Config struct {
+	// At least one of the keys is required, see NewClient.
+	SimpleAPIKey string `yaml:"simpleApiKey" json:"simpleApiKey"`
+	StudioAPIKey string `yaml:"studioApiKey" json:"studioApiKey"`
+	// See WithBaseURL.
+	BaseURL string `yaml:"baseUrl" json:"baseUrl"`
+	// Timeout of the http client in the format of time.ParseDuration, e.g.
+	// "30s". DefaultTimeout is used when empty.
+	Timeout string `yaml:"timeout" json:"timeout"`
+	// See WithRetries.
+	Retries int `yaml:"retries" json:"retries"`
+	// See WithRetryBudget.
+	RetryBudget float64 `yaml:"retryBudget" json:"retryBudget"`
+	// See WithDefaultWorkspace.
+	DefaultWorkspace string `yaml:"defaultWorkspace" json:"defaultWorkspace"`
+}
+
+// NewClientFromConfig creates a client configured with Config read from r as
+// JSON or YAML. Unknown fields are rejected to catch typos. Options are
+// applied after the ones derived from the config, so they take precedence.
+func NewClientFromConfig(r io.Reader, opts ...Option) (Client, error) {
+	var cfg Config
+	d := yaml.NewDecoder(r)
+	d.KnownFields(true)
+	if err := d.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Client{}, errors.Wrap(err, "reading config")
+	}
+
+	if cfg.SimpleAPIKey == "" && cfg.StudioAPIKey == "" {
+		return Client{}, errors.New("config: neither simpleApiKey nor studioApiKey is set")
+	}
+
+	timeout := DefaultTimeout
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return Client{}, errors.Wrap(err, "config: parsing timeout")
+		}
+		if timeout < 0 {
+			return Client{}, errors.Errorf("config: timeout must not be negative, got %s", cfg.Timeout)
+		}
+	}
+
+	if cfg.Retries < 0 {
+		return Client{}, errors.Errorf("config: retries must not be negative, got %d", cfg.Retries)
+	}
+
+	if cfg.RetryBudget < 0 {
+		return Client{}, errors.Errorf("config: retryBudget must not be negative, got %v", cfg.RetryBudget)
+	}
+
+	var configured []Option
+	if cfg.BaseURL != "" {
+		u, err := url.Parse(cfg.BaseURL)
+		if err != nil {
+			return Client{}, errors.Wrap(err, "config: parsing baseUrl")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return Client{}, errors.Errorf("config: baseUrl must be an absolute http(s) URL, got %q", cfg.BaseURL)
+		}
+		configured = append(configured, WithBaseURL(u))
+	}
+
+	if cfg.Retries > 0 {
+		configured = append(configured, WithRetries(cfg.Retries))
+	}
+
+	if cfg.RetryBudget > 0 {
+		configured = append(configured, WithRetryBudget(cfg.RetryBudget))
+	}
+
+	if cfg.DefaultWorkspace != "" {
+		configured = append(configured, WithDefaultWorkspace(cfg.DefaultWorkspace))
+	}
+
+	return NewClient(
+		cfg.SimpleAPIKey,
+		cfg.StudioAPIKey,
+		http.Client{Timeout: timeout},
+		append(configured, opts...)...,
+	), nil
+}
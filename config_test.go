@@ -0,0 +1,87 @@
+package inworld
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientFromConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		config      string
+		wantErr     string
+		wantTimeout time.Duration
+		wantRetries int
+		wantBaseURL string
+	}{
+		{
+			name: "yaml",
+			config: `
+studioApiKey: studio-key
+baseUrl: https://proxy.example.com/inworld
+timeout: 5s
+retries: 3
+retryBudget: 0.1
+defaultWorkspace: w
+`,
+			wantTimeout: 5 * time.Second,
+			wantRetries: 3,
+			wantBaseURL: "https://proxy.example.com/inworld",
+		},
+		{
+			name:        "json",
+			config:      `{"simpleApiKey": "simple-key", "timeout": "1m30s", "retries": 2}`,
+			wantTimeout: 90 * time.Second,
+			wantRetries: 2,
+		},
+		{
+			name:        "default timeout",
+			config:      `simpleApiKey: simple-key`,
+			wantTimeout: DefaultTimeout,
+		},
+		{name: "empty", config: ``, wantErr: "neither simpleApiKey nor studioApiKey"},
+		{name: "no keys", config: `timeout: 5s`, wantErr: "neither simpleApiKey nor studioApiKey"},
+		{name: "unknown field", config: "studioApiKey: k\nretires: 3\n", wantErr: "retires"},
+		{name: "unknown json field", config: `{"studioApiKey": "k", "baseURL": "https://x"}`, wantErr: "baseURL"},
+		{name: "malformed timeout", config: "studioApiKey: k\ntimeout: 5\n", wantErr: "parsing timeout"},
+		{name: "negative timeout", config: "studioApiKey: k\ntimeout: -1s\n", wantErr: "timeout must not be negative"},
+		{name: "negative retries", config: "studioApiKey: k\nretries: -1\n", wantErr: "retries must not be negative"},
+		{name: "negative budget", config: "studioApiKey: k\nretryBudget: -0.5\n", wantErr: "retryBudget must not be negative"},
+		{name: "relative base url", config: "studioApiKey: k\nbaseUrl: proxy/inworld\n", wantErr: "absolute http(s) URL"},
+		{name: "base url scheme", config: "studioApiKey: k\nbaseUrl: ftp://proxy\n", wantErr: "absolute http(s) URL"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClientFromConfig(strings.NewReader(tt.config))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error is %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.client.Timeout != tt.wantTimeout {
+				t.Errorf("timeout is %s, want %s", c.client.Timeout, tt.wantTimeout)
+			}
+
+			var retries int
+			if c.retry != nil {
+				retries = c.retry.maxRetries
+			}
+			if retries != tt.wantRetries {
+				t.Errorf("retries is %d, want %d", retries, tt.wantRetries)
+			}
+
+			var baseURL string
+			if c.baseURL != nil {
+				baseURL = c.baseURL.String()
+			}
+			if baseURL != tt.wantBaseURL {
+				t.Errorf("base url is %q, want %q", baseURL, tt.wantBaseURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package inworld
+
+import (
+	"context"
+	"sync"
+)
+
+// SimpleConversation is a lightweight multi-turn chat with a single character
+// on top of SimpleSendText. The first turn lets the server create a session,
+// its id is remembered and sent with every subsequent turn, so the character
+// keeps the context of the conversation. SimpleConversation is safe for
+// concurrent use, turns are sent one at a time.
+type SimpleConversation struct {
+	client          Client
+	character       string
+	endUserID       string
+	endUserFullname string
+
+	mu           sync.Mutex
+	sessionID    string
+	relationship RelationshipUpdate
+}
+
+// NewSimpleConversation creates a conversation between the end user and the
+// character. Format of the character:
+// workspaces/{workspace}/characters/{character}. The id and the full name of
+// the end user are sent as SimpleSendTextRequest.EndUserID and
+// SimpleSendTextRequest.EndUserFullname, both are optional. SimpleSendText
+// accepts no other information about the end user, open a session with
+// OpenSession to pass EndUserConfig.
+func (c Client) NewSimpleConversation(character, endUserID, endUserFullname string) *SimpleConversation {
+	return &SimpleConversation{
+		client:          c,
+		character:       character,
+		endUserID:       endUserID,
+		endUserFullname: endUserFullname,
+	}
+}
+
+// Say sends the text to the character and returns its response. Emotion of the
+// character and the relationship changes caused by this turn are available in
+// the returned Interaction.
+func (s *SimpleConversation) Say(ctx context.Context, text string) (Interaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.client.SimpleSendText(ctx, SimpleSendTextRequest{
+		Character:       s.character,
+		Text:            text,
+		SessionID:       s.sessionID,
+		EndUserID:       s.endUserID,
+		EndUserFullname: s.endUserFullname,
+	})
+	if err != nil {
+		return Interaction{}, err
+	}
+
+	if i.SessionID != "" {
+		s.sessionID = i.SessionID
+	}
+
+	s.relationship.Trust += i.RelationshipUpdate.Trust
+	s.relationship.Respect += i.RelationshipUpdate.Respect
+	s.relationship.Familiar += i.RelationshipUpdate.Familiar
+	s.relationship.Flirtatious += i.RelationshipUpdate.Flirtatious
+	s.relationship.Attraction += i.RelationshipUpdate.Attraction
+
+	return i, nil
+}
+
+// SessionID returns the id of the session used by the conversation. It is
+// empty until the first successful turn.
+func (s *SimpleConversation) SessionID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessionID
+}
+
+// Relationship returns the sum of all relationship updates received during the
+// conversation.
+func (s *SimpleConversation) Relationship() RelationshipUpdate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.relationship
+}
+
+// SimpleSendTextResult is the result of SimpleSendTextInSession.
+type SimpleSendTextResult struct {
+	Interaction Interaction
+	// NewSession reports whether the server has created a new session for the
+	// request, i.e. the character didn't remember any previous turns.
+	NewSession bool
+	// Conversation continues the session of the interaction.
+	Conversation *SimpleConversation
+}
+
+// SimpleSendTextInSession sends the text like SimpleSendText does and returns
+// the conversation continuing the session used by the server, so subsequent
+// turns don't create new sessions.
+func (c Client) SimpleSendTextInSession(ctx context.Context, req SimpleSendTextRequest) (SimpleSendTextResult, error) {
+	i, err := c.SimpleSendText(ctx, req)
+	if err != nil {
+		return SimpleSendTextResult{}, err
+	}
+
+	sessionID := i.SessionID
+	if sessionID == "" {
+		sessionID = req.SessionID
+	}
+
+	return SimpleSendTextResult{
+		Interaction: i,
+		NewSession:  req.SessionID == "" || sessionID != req.SessionID,
+		Conversation: &SimpleConversation{
+			client:          c,
+			character:       req.Character,
+			endUserID:       req.EndUserID,
+			endUserFullname: req.EndUserFullname,
+			sessionID:       sessionID,
+			relationship:    i.RelationshipUpdate,
+		},
+	}, nil
+}
@@ -0,0 +1,45 @@
+package inworld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSimpleConversation(t *testing.T) {
+	var turns []SimpleSendTextRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req SimpleSendTextRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		turns = append(turns, req)
+		_, _ = w.Write([]byte(`{"sessionId":"s","textList":["Hi!"],"relationshipUpdate":{"trust":1}}`))
+	})
+
+	conv := c.NewSimpleConversation("workspaces/w/characters/c", "user-1", "Ada Lovelace")
+	for _, text := range []string{"Hello", "How are you?"} {
+		if _, err := conv.Say(context.Background(), text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []SimpleSendTextRequest{
+		{Character: "workspaces/w/characters/c", Text: "Hello", EndUserID: "user-1", EndUserFullname: "Ada Lovelace"},
+		{Character: "workspaces/w/characters/c", Text: "How are you?", SessionID: "s", EndUserID: "user-1", EndUserFullname: "Ada Lovelace"},
+	}
+	if len(turns) != len(want) {
+		t.Fatalf("got %d turns, want %d", len(turns), len(want))
+	}
+	for i := range want {
+		if turns[i] != want[i] {
+			t.Errorf("turn %d is %+v, want %+v", i, turns[i], want[i])
+		}
+	}
+
+	if got := conv.Relationship().Trust; got != 2 {
+		t.Errorf("trust is %d, want 2", got)
+	}
+}
@@ -0,0 +1,163 @@
+package inworld
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AuthScheme is the scheme of the Authorization header.
+type AuthScheme string
+
+const (
+	// AuthSchemeBasic is used with API keys copied from studio in Base64.
+	AuthSchemeBasic AuthScheme = "Basic"
+	// AuthSchemeBearer is used with bearer tokens, e.g. JWT.
+	AuthSchemeBearer AuthScheme = "Bearer"
+)
+
+// CredentialSource provides credentials to authorize requests, which allows
+// integrating OAuth or a secrets manager.
+type CredentialSource interface {
+	// Token returns the credential and the time it expires at. Zero time
+	// means the credential never expires.
+	Token(ctx context.Context) (token string, expiry time.Time, err error)
+}
+
+// TokenSource returns a bearer token used to authorize a request. It is called
+// before every request, so it is responsible for caching and refreshing the
+// token, and must be safe for concurrent use.
+type TokenSource func(ctx context.Context) (string, error)
+
+// Token implements CredentialSource. Tokens are considered to be never
+// expiring, because TokenSource is responsible for refreshing them.
+func (ts TokenSource) Token(ctx context.Context) (string, time.Time, error) {
+	token, err := ts(ctx)
+	return token, time.Time{}, err
+}
+
+// StaticCredentials returns a CredentialSource that always returns the key,
+// e.g. an API key.
+func StaticCredentials(key string) CredentialSource {
+	return TokenSource(func(context.Context) (string, error) { return key, nil })
+}
+
+// DefaultExpiryMargin is the time before the expiry at which credentials are
+// refreshed by the client.
+const DefaultExpiryMargin = time.Minute
+
+// CachedCredentials returns a CredentialSource that caches the credentials of
+// src and requests new ones when less than margin is left before their expiry.
+// It is safe for concurrent use, concurrent requests for expired credentials
+// wait for a single refresh or until their contexts are done. If the refresh
+// fails, one of the waiting requests tries again. The client checks the
+// expiry with its Clock, see WithClock.
+func CachedCredentials(src CredentialSource, margin time.Duration) CredentialSource {
+	if c, ok := src.(*cachedCredentials); ok && c.margin == margin {
+		return c
+	}
+	return &cachedCredentials{src: src, margin: margin}
+}
+
+type cachedCredentials struct {
+	src    CredentialSource
+	margin time.Duration
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	valid  bool
+	// Closed when the refresh in progress is over, nil if there is none.
+	refreshed chan struct{}
+}
+
+// Token implements CredentialSource.
+func (c *cachedCredentials) Token(ctx context.Context) (string, time.Time, error) {
+	return c.tokenAt(ctx, realClock{})
+}
+
+// tokenAt returns the cached credentials if they are still valid at the time
+// of the clock, otherwise it refreshes them or waits for the refresh in
+// progress.
+func (c *cachedCredentials) tokenAt(ctx context.Context, clock Clock) (string, time.Time, error) {
+	for {
+		c.mu.Lock()
+		if c.valid && (c.expiry.IsZero() || c.expiry.Sub(clock.Now()) > c.margin) {
+			token, expiry := c.token, c.expiry
+			c.mu.Unlock()
+			return token, expiry, nil
+		}
+
+		refreshed := c.refreshed
+		if refreshed == nil {
+			c.refreshed = make(chan struct{})
+			c.mu.Unlock()
+			return c.refresh(ctx)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-refreshed:
+		case <-ctx.Done():
+			return "", time.Time{}, errors.WithStack(ctx.Err())
+		}
+	}
+}
+
+// refresh requests new credentials and wakes up the requests waiting for them.
+func (c *cachedCredentials) refresh(ctx context.Context) (string, time.Time, error) {
+	token, expiry, err := c.src.Token(ctx)
+
+	c.mu.Lock()
+	if err == nil {
+		c.token, c.expiry, c.valid = token, expiry, true
+	}
+	close(c.refreshed)
+	c.refreshed = nil
+	c.mu.Unlock()
+
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiry, nil
+}
+
+// credentials used by the client, zero value means the API key with the Basic
+// scheme.
+type credentials struct {
+	scheme AuthScheme
+	src    CredentialSource
+}
+
+// basicCredentials encodes the user and the password for the Basic scheme.
+func basicCredentials(user, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+}
+
+func authorize(r *http.Request, creds credentials, key string, clock Clock) error {
+	if creds.src == nil {
+		r.Header.Set("Authorization", string(AuthSchemeBasic)+" "+key)
+		return nil
+	}
+
+	var (
+		token string
+		err   error
+	)
+	if cached, ok := creds.src.(*cachedCredentials); ok {
+		token, _, err = cached.tokenAt(r.Context(), clock)
+	} else {
+		token, _, err = creds.src.Token(r.Context())
+	}
+	if err != nil {
+		return errors.Wrap(err, "getting credentials")
+	}
+
+	r.Header.Set("Authorization", string(creds.scheme)+" "+token)
+	return nil
+}
@@ -0,0 +1,118 @@
+package inworld
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fixedClock struct{ now time.Time }
+
+func (c *fixedClock) Now() time.Time { return c.now }
+
+func (c *fixedClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.now = c.now.Add(d)
+	return ctx.Err()
+}
+
+func TestCachedCredentialsWaitersHonourContext(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	src := CachedCredentials(TokenSource(func(context.Context) (string, error) {
+		close(started)
+		<-release
+		return "token", nil
+	}), time.Minute).(*cachedCredentials)
+
+	done := make(chan error)
+	go func() {
+		_, _, err := src.Token(context.Background())
+		done <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := src.Token(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waiter returned %v, want deadline exceeded", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if token, _, err := src.Token(context.Background()); err != nil || token != "token" {
+		t.Errorf("cached token is %q, %v", token, err)
+	}
+}
+
+func TestCachedCredentialsUseClock(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var calls atomic.Int64
+	src := CachedCredentials(credentialSourceFunc(func(context.Context) (string, time.Time, error) {
+		calls.Add(1)
+		return "token", clock.now.Add(time.Hour), nil
+	}), time.Minute).(*cachedCredentials)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := src.tokenAt(context.Background(), clock); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("%d refreshes before the expiry, want 1", n)
+	}
+
+	clock.now = clock.now.Add(time.Hour - time.Minute)
+	if _, _, err := src.tokenAt(context.Background(), clock); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("%d refreshes within the margin, want 2", n)
+	}
+}
+
+type credentialSourceFunc func(context.Context) (string, time.Time, error)
+
+func (f credentialSourceFunc) Token(ctx context.Context) (string, time.Time, error) { return f(ctx) }
+
+func TestStudioBasicCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		header string
+	}{
+		{name: "default", header: "Basic studio-key"},
+		{
+			name:   "key and secret",
+			opts:   []Option{WithStudioBasicCredentials("key", "secret")},
+			header: "Basic " + base64.StdEncoding.EncodeToString([]byte("key:secret")),
+		},
+		{
+			// Only the first colon separates the key from the secret.
+			name:   "secret with colon",
+			opts:   []Option{WithStudioBasicCredentials("key", "se:cr:et")},
+			header: "Basic a2V5OnNlOmNyOmV0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tt.header {
+					t.Errorf("got Authorization %q, want %q", got, tt.header)
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}, tt.opts...)
+
+			if _, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", ""); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
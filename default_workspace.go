@@ -0,0 +1,38 @@
+package inworld
+
+import (
+	"context"
+)
+
+// CreateCharacterInDefaultWorkspace is like CreateCharacter in the workspace
+// set with WithDefaultWorkspace.
+func (c Client) CreateCharacterInDefaultWorkspace(ctx context.Context, ch Character) (Character, error) {
+	return c.CreateCharacter(ctx, "", ch)
+}
+
+// CreateSceneInDefaultWorkspace is like CreateScene in the workspace set with
+// WithDefaultWorkspace.
+func (c Client) CreateSceneInDefaultWorkspace(ctx context.Context, scene Scene) (Scene, error) {
+	return c.CreateScene(ctx, "", scene)
+}
+
+// CreateCommonKnowledgeInDefaultWorkspace is like CreateCommonKnowledge in the
+// workspace set with WithDefaultWorkspace.
+func (c Client) CreateCommonKnowledgeInDefaultWorkspace(
+	ctx context.Context,
+	k CommonKnowledge,
+) (CommonKnowledge, error) {
+	return c.CreateCommonKnowledge(ctx, "", k)
+}
+
+// ListVoicesInDefaultWorkspace is like ListVoices in the workspace set with
+// WithDefaultWorkspace.
+func (c Client) ListVoicesInDefaultWorkspace(ctx context.Context, opts ...VoiceOption) ([]StudioBaseVoice, error) {
+	return c.ListVoices(ctx, "", opts...)
+}
+
+// GetDefaultWorkspace is like GetWorkspace for the workspace set with
+// WithDefaultWorkspace.
+func (c Client) GetDefaultWorkspace(ctx context.Context) (Workspace, error) {
+	return c.GetWorkspace(ctx, "")
+}
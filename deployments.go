@@ -3,6 +3,8 @@ package inworld
 import (
 	"context"
 	"net/http"
+	neturl "net/url"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -38,21 +40,248 @@ func (c Client) CheckDeploymentStatus(ctx context.Context, operationID string) (
 	return sendStudioAPIRequest[CheckDeploymentStatusResponse](c, r)
 }
 
+// CheckDeploymentStatuses checks the status of each operation, see
+// CheckDeploymentStatus. The API can't return several operations at once, so
+// up to WithPollConcurrency operations are requested concurrently, other wait
+// options are ignored. Statuses are returned in the order of the operations,
+// a zero status stands for each failed one. The returned error joins the
+// errors of all failed checks.
+func (c Client) CheckDeploymentStatuses(
+	ctx context.Context,
+	operationNames []string,
+	opts ...WaitOption,
+) ([]CheckDeploymentStatusResponse, error) {
+	o := newWaitOptions(opts)
+	res := make([]CheckDeploymentStatusResponse, len(operationNames))
+
+	err := runConcurrently(ctx, len(operationNames), o.concurrency, func(i int) error {
+		resp, err := c.CheckDeploymentStatus(ctx, operationNames[i])
+		if err != nil {
+			return errors.Wrapf(err, "checking %s", operationNames[i])
+		}
+
+		res[i] = resp
+		return nil
+	})
+
+	return res, err
+}
+
+// LatestDeploymentStatus returns the status of the most recent long-running
+// operation of the resource, e.g. of the latest deployment of a character.
+// Operations are ordered by OperationMetadata.CreateTime, the order of the
+// server is kept when it is not reported. ErrNotFound is returned if the
+// resource has no operations. Format of the resource name:
+// workspaces/{workspace}/characters/{character} or
+// workspaces/{workspace}/scenes/{scene} or
+// workspaces/{workspace}/common-knowledge/{common_knowledge}
+// There is no documentation for this method.
+func (c Client) LatestDeploymentStatus(ctx context.Context, resourceName string) (CheckDeploymentStatusResponse, error) {
+	if resourceName == "" {
+		return CheckDeploymentStatusResponse{}, errors.New("resource name is required")
+	}
+
+	var (
+		latest    CheckDeploymentStatusResponse
+		found     bool
+		pageToken string
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return CheckDeploymentStatusResponse{}, err
+		}
+
+		url := apiStudioV1.JoinPath(resourceName, "operations")
+		if pageToken != "" {
+			url.RawQuery = "pageToken=" + neturl.QueryEscape(pageToken)
+		}
+
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), http.NoBody)
+		if err != nil {
+			return CheckDeploymentStatusResponse{}, errors.WithStack(err)
+		}
+
+		resp, err := sendStudioAPIRequest[listOperationsResponse](c, r)
+		if err != nil {
+			return CheckDeploymentStatusResponse{}, err
+		}
+
+		for _, op := range resp.Operations {
+			if !found || op.Metadata.CreateTime.After(latest.Metadata.CreateTime) {
+				latest, found = op, true
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	if !found {
+		return CheckDeploymentStatusResponse{}, errors.Wrapf(ErrNotFound, "no operations of %s", resourceName)
+	}
+
+	return latest, nil
+}
+
+// listOperationsResponse is the response of listing long-running operations.
+type listOperationsResponse struct {
+	Operations    []CheckDeploymentStatusResponse `json:"operations"`
+	NextPageToken string                          `json:"nextPageToken"`
+}
+
+// Operation is a long-running operation started by a deployment or another
+// asynchronous method, e.g. DeployCharacter or RenderAvatarImages.
+type Operation struct {
+	// Format: workspaces/{workspace}/{resource}/{id}/operations/{operation}
+	Name string
+}
+
+// Operation returns the long-running operation of the deployment.
+func (r DeploymentResponse) Operation() Operation { return Operation{Name: r.Name} }
+
+// startOperation sends the request starting a long-running operation.
+func startOperation(c Client, r *http.Request) (Operation, error) {
+	resp, err := sendStudioAPIRequest[DeploymentResponse](c, r)
+	if err != nil {
+		return Operation{}, err
+	}
+	return resp.Operation(), nil
+}
+
+// OperationStatus checks the status of the operation once, see
+// CheckDeploymentStatus.
+func (c Client) OperationStatus(ctx context.Context, op Operation) (CheckDeploymentStatusResponse, error) {
+	return c.CheckDeploymentStatus(ctx, op.Name)
+}
+
+// WaitForDeployment polls the status of the long-running operation until it is
+// done or the context is canceled, see PollOperation.
+func (c Client) WaitForDeployment(
+	ctx context.Context,
+	operationName string,
+	opts ...WaitOption,
+) (CheckDeploymentStatusResponse, error) {
+	return c.PollOperation(ctx, Operation{Name: operationName}, opts...)
+}
+
+// PollOperation polls the status of the long-running operation until it is
+// done or the context is canceled. If the operation has failed, its status is
+// returned along with the error of the operation, see
+// CheckDeploymentStatusResponse.Err. The name of the operation is checked
+// before the first request, see Operation.
+func (c Client) PollOperation(ctx context.Context, op Operation, opts ...WaitOption) (CheckDeploymentStatusResponse, error) {
+	if err := checkOperationName(op.Name); err != nil {
+		return CheckDeploymentStatusResponse{}, err
+	}
+
+	o := newWaitOptions(opts)
+
+	for {
+		resp, err := c.OperationStatus(ctx, op)
+		if err != nil {
+			return CheckDeploymentStatusResponse{}, err
+		}
+
+		if o.progress != nil {
+			o.progress(resp)
+		}
+
+		if resp.Done {
+			return resp, errors.WithStack(resp.Err())
+		}
+
+		if err = c.getClock().Sleep(ctx, o.interval); err != nil {
+			return resp, err
+		}
+	}
+}
+
+// WaitForDeployments waits for all the operations concurrently, see
+// PollOperation. Statuses are returned in the order of the operations, the
+// returned error joins the errors of all failed operations. Waiting for all
+// operations stops when the context is canceled.
+func (c Client) WaitForDeployments(
+	ctx context.Context,
+	ops []Operation,
+	opts ...WaitOption,
+) ([]CheckDeploymentStatusResponse, error) {
+	o := newWaitOptions(opts)
+	res := make([]CheckDeploymentStatusResponse, len(ops))
+
+	err := runConcurrently(ctx, len(ops), o.concurrency, func(i int) error {
+		resp, err := c.PollOperation(ctx, ops[i], opts...)
+		res[i] = resp
+		return errors.Wrapf(err, "waiting for %s", ops[i].Name)
+	})
+
+	return res, err
+}
+
+// WaitOption configures waiting for long-running operations.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	interval    time.Duration
+	concurrency int
+	progress    func(CheckDeploymentStatusResponse)
+}
+
+func newWaitOptions(opts []WaitOption) waitOptions {
+	o := waitOptions{interval: 2 * time.Second, concurrency: 4}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithPollInterval sets the interval between status checks. Default is 2
+// seconds.
+func WithPollInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) { o.interval = d }
+}
+
+// WithPollConcurrency sets the max number of operations polled at once by
+// WaitForDeployments and CheckDeploymentStatuses. Default is 4.
+func WithPollConcurrency(n int) WaitOption {
+	return func(o *waitOptions) { o.concurrency = n }
+}
+
+// WithProgressFunc sets the function called with the status of the operation
+// on each poll, see CheckDeploymentStatusResponse.Progress. WaitForDeployments
+// calls it concurrently for different operations.
+func WithProgressFunc(f func(CheckDeploymentStatusResponse)) WaitOption {
+	return func(o *waitOptions) { o.progress = f }
+}
+
 // CheckDeploymentStatusResponse represents the result of checking the
 // deployment status. This object has no documentation.
 // There is no documentation for this object.
 type CheckDeploymentStatusResponse struct {
-	Name     string `json:"name"`
-	Metadata struct {
-		Type string `json:"@type"`
-	} `json:"metadata"`
-	Done     bool `json:"done"`
+	Name     string            `json:"name"`
+	Metadata OperationMetadata `json:"metadata"`
+	Done     bool              `json:"done"`
 	Response struct {
 		Type string `json:"@type"`
 	} `json:"response"`
+	// Error of the failed operation, nil if the operation is not done or has
+	// succeeded.
+	Error *Error `json:"error,omitempty"`
 }
 
-// DeploymentResponse represents the result of the deployment.
+// Err returns the error of the failed operation, nil if the operation is not
+// done or has succeeded.
+func (r CheckDeploymentStatusResponse) Err() error {
+	if r.Error == nil {
+		return nil
+	}
+	return r.Error
+}
+
+// DeploymentResponse represents the result of the deployment as it is returned
+// by the server, the deploy methods return its Operation.
 // This object has no documentation.
 type DeploymentResponse struct {
 	// Format:
@@ -61,9 +290,34 @@ type DeploymentResponse struct {
 	// workspaces/{workspace_id}/characters/{character_name}/operations/{operation_id}
 	// or
 	// workspaces/{workspace_id}/common-knowledge/{common_knowledge_id}/operations/{operation_id}
-	Name     string `json:"name"`
-	Metadata struct {
-		Type string `json:"@type"`
-	} `json:"metadata"`
-	Done bool `json:"done"`
+	Name     string            `json:"name"`
+	Metadata OperationMetadata `json:"metadata"`
+	Done     bool              `json:"done"`
+}
+
+// OperationMetadata is the metadata of a long-running operation.
+// There is no documentation for this object.
+type OperationMetadata struct {
+	Type string `json:"@type"`
+	// Time the operation was started. There is no documentation for this
+	// field, the server may not report it. Optional.
+	CreateTime time.Time `json:"createTime"`
+	// Completion percentage of the operation. There is no documentation for
+	// this field, the server may not report it. Optional.
+	ProgressPercent *float64 `json:"progressPercent,omitempty"`
+}
+
+// Progress returns the completion percentage of the operation in the range
+// [0, 100]. A done operation is always complete, ok is false if the server
+// hasn't reported the progress.
+func (r CheckDeploymentStatusResponse) Progress() (percent int, ok bool) {
+	if r.Done {
+		return 100, true
+	}
+
+	if r.Metadata.ProgressPercent == nil {
+		return 0, false
+	}
+
+	return min(max(int(*r.Metadata.ProgressPercent), 0), 100), true
 }
@@ -0,0 +1,47 @@
+package inworld
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDeployAndPollOperation(t *testing.T) {
+	const opName = "workspaces/w/characters/c/operations/op"
+
+	var polls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/workspaces/w/characters/c:deploy"):
+			_, _ = w.Write([]byte(`{"name":"` + opName + `","done":false}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/"+opName):
+			done := polls.Add(1) == 3
+			if done {
+				_, _ = w.Write([]byte(`{"name":"` + opName + `","done":true}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"` + opName + `","done":false}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}, WithClock(noSleepClock{}))
+
+	op, err := c.DeployCharacter(context.Background(), "workspaces/w/characters/c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Name != opName {
+		t.Fatalf("operation is %q, want %q", op.Name, opName)
+	}
+
+	status, err := c.PollOperation(context.Background(), op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Done || polls.Load() != 3 {
+		t.Errorf("status %+v after %d polls", status, polls.Load())
+	}
+}
@@ -0,0 +1,220 @@
+package inworld
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EqualIgnoringServerFields reports whether the characters are equal ignoring
+// the fields managed by the server, see Character.Diff.
+func (c Character) EqualIgnoringServerFields(other Character) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns JSON paths of the fields that differ between the characters,
+// e.g. "defaultCharacterDescription.givenName" or "commonKnowledge[1]".
+// Output-only fields (Name, CreateTime, UpdateTime, Meta, SharePortalInfo,
+// InworldTags, Scenes) and generated UUIDs of personal knowledge and custom
+// dialog styles are ignored.
+// Nil and empty slices, maps and pointers to zero values are considered equal.
+func (c Character) Diff(other Character) []string {
+	return diff(c.withoutServerFields(), other.withoutServerFields())
+}
+
+// ContentHash returns the hex-encoded SHA-256 hash of the fields compared by
+// Diff. The hashed top-level fields are DefaultCharacterDescription,
+// DefaultCharacterAssets, InitialMood, Personality, CommonKnowledge,
+// SocialRank, PersonalKnowledge, EmotionalFluidity, YamlConfig, SafetyConfig,
+// Relationship, CognitiveControl, Language, BehavioralContexts, FourthWall,
+// UserTags and LongTermCoherence. The output-only fields (Name, CreateTime,
+// UpdateTime, Meta, SharePortalInfo, InworldTags, Scenes) and the generated
+// UUIDs of personal knowledge and custom dialog styles are not hashed.
+// Characters equal by EqualIgnoringServerFields have the same hash, e.g. the
+// character sent to CreateCharacter and the created one. Fields with zero
+// values don't affect the hash, so it doesn't change when fields are added to
+// Character.
+func (c Character) ContentHash() string {
+	h := sha256.New()
+	hashValues(h, "", reflect.ValueOf(c.withoutServerFields()))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// withoutServerFields returns a copy of the character with all the fields
+// managed by the server cleared.
+func (c Character) withoutServerFields() Character {
+	c = c.forRequest()
+
+	if c.PersonalKnowledge != nil {
+		pk := *c.PersonalKnowledge
+		pk.UUID = ""
+		c.PersonalKnowledge = &pk
+	}
+
+	if styles := c.DefaultCharacterDescription.CustomDialogStyles; styles != nil {
+		c.DefaultCharacterDescription.CustomDialogStyles = make([]CustomDialogStyle, len(styles))
+		for i, s := range styles {
+			s.UUID = ""
+			c.DefaultCharacterDescription.CustomDialogStyles[i] = s
+		}
+	}
+
+	return c
+}
+
+// diff returns JSON paths of the differing fields of a and b, which must be of
+// the same type.
+func diff(a, b any) []string {
+	var res []string
+	diffValues("", reflect.ValueOf(a), reflect.ValueOf(b), &res)
+	return res
+}
+
+func diffValues(path string, a, b reflect.Value, res *[]string) {
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() && b.IsNil() {
+			return
+		}
+		diffValues(path, elemOrZero(a), elemOrZero(b), res)
+
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			name := jsonName(f)
+			if name == "-" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+
+			diffValues(name, a.Field(i), b.Field(i), res)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			*res = append(*res, path)
+			return
+		}
+		for i := 0; i < a.Len(); i++ {
+			diffValues(path+"["+strconv.Itoa(i)+"]", a.Index(i), b.Index(i), res)
+		}
+
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			k := keys[name]
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			if !av.IsValid() {
+				av = reflect.Zero(a.Type().Elem())
+			}
+			if !bv.IsValid() {
+				bv = reflect.Zero(b.Type().Elem())
+			}
+			diffValues(path+"["+name+"]", av, bv, res)
+		}
+
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*res = append(*res, path)
+		}
+	}
+}
+
+// hashValues writes the non-zero values of v to w one per line prefixed with
+// their JSON paths, like diffValues walks them.
+func hashValues(w io.Writer, path string, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			hashValues(w, path, v.Elem())
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			name := jsonName(f)
+			if name == "-" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+
+			hashValues(w, name, v.Field(i))
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v.Len() > 0 {
+			fmt.Fprintf(w, "%s#%d\n", path, v.Len())
+		}
+		for i := 0; i < v.Len(); i++ {
+			hashValues(w, path+"["+strconv.Itoa(i)+"]", v.Index(i))
+		}
+
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, k := range v.MapKeys() {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			hashValues(w, path+"["+name+"]", v.MapIndex(keys[name]))
+		}
+
+	default:
+		if v.IsValid() && !v.IsZero() {
+			b, _ := json.Marshal(v.Interface())
+			fmt.Fprintf(w, "%s=%s\n", path, b)
+		}
+	}
+}
+
+func elemOrZero(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+	return v.Elem()
+}
+
+// jsonName returns the name of the field in JSON.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
@@ -0,0 +1,53 @@
+package inworld
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEqualIgnoringServerFields(t *testing.T) {
+	sent := Character{
+		DefaultCharacterDescription: CharacterDescription{
+			GivenName:          "Ada",
+			Description:        "A mathematician.",
+			CustomDialogStyles: []CustomDialogStyle{{DisplayName: "formal", IsActive: true}},
+		},
+		CommonKnowledge:   []string{"workspaces/w/common-knowledge/k"},
+		PersonalKnowledge: &PersonalKnowledge{Facts: []Fact{{Text: "Born in 1815."}}},
+	}
+
+	now := time.Now()
+	created := sent
+	created.Name = "workspaces/w/characters/ada"
+	created.CreateTime, created.UpdateTime = &now, &now
+	created.Meta = &Meta{TotalCommonKnowledge: 1}
+	created.SharePortalInfo = &SharePortalInfo{PortalURL: "https://example.com/ada"}
+	created.InworldTags = []Tag{{ID: "t", Name: "featured"}}
+	created.Scenes = []any{"workspaces/w/scenes/s"}
+	created.PersonalKnowledge = &PersonalKnowledge{UUID: "pk-uuid", Facts: []Fact{{Text: "Born in 1815."}}}
+	created.DefaultCharacterDescription.CustomDialogStyles = []CustomDialogStyle{
+		{UUID: "style-uuid", DisplayName: "formal", IsActive: true},
+	}
+
+	if !sent.EqualIgnoringServerFields(created) {
+		t.Errorf("characters differing in server fields only are not equal: %v", sent.Diff(created))
+	}
+	if sent.ContentHash() != created.ContentHash() {
+		t.Error("characters differing in server fields only have different hashes")
+	}
+
+	// The server fields of the compared characters are kept.
+	if created.PersonalKnowledge.UUID != "pk-uuid" || created.DefaultCharacterDescription.CustomDialogStyles[0].UUID != "style-uuid" {
+		t.Error("compared character is changed")
+	}
+
+	edited := created
+	edited.DefaultCharacterDescription.Description = "A poet."
+	if sent.EqualIgnoringServerFields(edited) {
+		t.Error("characters with different descriptions are equal")
+	}
+	if got, want := sent.Diff(edited), []string{"defaultCharacterDescription.description"}; !slices.Equal(got, want) {
+		t.Errorf("diff is %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package inworld
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Auth selects the credentials used by Do.
+type Auth int
+
+const (
+	// AuthStudio authorizes the request with the studio API key.
+	AuthStudio Auth = iota
+	// AuthSimple authorizes the request with the simple API key.
+	AuthSimple
+)
+
+// Do is an escape hatch for the endpoints not modeled by this package. It
+// sends the request authorized according to auth and handles the response the
+// same way other methods do, decoding it into T. The path is relative to
+// https://api.inworld.ai and may contain a query, e.g.
+// "studio/v1/workspaces/{workspace}/characters?pageSize=10". Absolute URLs and
+// paths with a host (e.g. "//example.com/...") are rejected. The body is
+// encoded to JSON, nil means no body.
+func Do[T any](ctx context.Context, c Client, auth Auth, method, path string, body any) (T, error) {
+	var zero T
+
+	if method == "" {
+		return zero, errors.New("method is required")
+	}
+
+	if path == "" {
+		return zero, errors.New("path is required")
+	}
+
+	ref, err := url.Parse(path)
+	if err != nil {
+		return zero, errors.WithStack(err)
+	}
+
+	// The keys must not be sent to other hosts.
+	if ref.IsAbs() || ref.Host != "" {
+		return zero, errors.Errorf("path %q must be relative", path)
+	}
+
+	u := api.ResolveReference(ref)
+
+	var b io.Reader = http.NoBody
+	if body != nil {
+		b = newReader(body)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, u.String(), b)
+	if err != nil {
+		return zero, errors.WithStack(err)
+	}
+
+	switch auth {
+	case AuthStudio:
+		return sendStudioAPIRequest[T](c, r)
+	case AuthSimple:
+		return sendSimpleAPIRequest[T](c, r, "")
+	default:
+		return zero, errors.Errorf("unknown auth %d", auth)
+	}
+}
@@ -0,0 +1,50 @@
+package inworld
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDoPath(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		path     string
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name:     "relative",
+			path:     "studio/v1/workspaces/w/characters?pageSize=10",
+			wantPath: "/studio/v1/workspaces/w/characters?pageSize=10",
+		},
+		{
+			name:     "rooted",
+			path:     "/studio/v1/workspaces/w",
+			wantPath: "/studio/v1/workspaces/w",
+		},
+		{name: "absolute", path: "https://example.com/studio/v1/workspaces/w", wantErr: true},
+		{name: "other scheme", path: "http:studio/v1/workspaces/w", wantErr: true},
+		{name: "host", path: "//example.com/studio/v1/workspaces/w", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if got := r.URL.RequestURI(); got != tt.wantPath {
+					t.Errorf("path is %q, want %q", got, tt.wantPath)
+				}
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			_, err := Do[struct{}](context.Background(), c, AuthStudio, http.MethodGet, tt.path, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error is %v, want error %t", err, tt.wantErr)
+			}
+			if n := requests.Load(); tt.wantErr && n != 0 {
+				t.Errorf("%d requests sent for a rejected path", n)
+			}
+		})
+	}
+}
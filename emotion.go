@@ -0,0 +1,114 @@
+package inworld
+
+import (
+	"maps"
+	"slices"
+)
+
+// EmotionTracker accumulates emotions and relationship updates of the
+// interactions of a conversation. The zero value is ready to use. It's not
+// safe for concurrent use.
+type EmotionTracker struct {
+	turns        int
+	counts       map[SpaffCode]int
+	firstSeen    []SpaffCode
+	last         SpaffCode
+	transitions  []EmotionTransition
+	strengthSum  int
+	strengths    int
+	relationship RelationshipUpdate
+}
+
+// EmotionTransition is a change of the emotion between two observed
+// interactions.
+type EmotionTransition struct {
+	// Turn is the index of the interaction showing the new emotion, counting
+	// from 0 among all observed interactions.
+	Turn     int
+	From, To SpaffCode
+}
+
+// EmotionSummary is the summary of the interactions observed by
+// EmotionTracker.
+type EmotionSummary struct {
+	// Number of observed interactions.
+	Turns int
+	// Most frequent emotion, the earliest one wins a tie. Empty if no emotion
+	// was observed.
+	Dominant SpaffCode
+	// Number of interactions with each emotion.
+	Counts map[SpaffCode]int
+	// Changes of the emotion in the order of the interactions.
+	Transitions []EmotionTransition
+	// Average strength of the emotions, where StrengthWeak is 1, StrengthNormal
+	// is 2 and StrengthStrong is 3. Zero if no strength was observed.
+	AverageStrength float64
+	// Sum of the relationship updates of all interactions.
+	Relationship RelationshipUpdate
+}
+
+// strengthWeights are the values of strengths averaged by EmotionTracker.
+var strengthWeights = map[Strength]int{
+	StrengthWeak:   1,
+	StrengthNormal: 2,
+	StrengthStrong: 3,
+}
+
+// Observe adds the interaction to the tracker. Unspecified emotions (mostly
+// error responses) and strengths are counted as turns but don't affect the
+// emotion statistics.
+func (t *EmotionTracker) Observe(i Interaction) {
+	turn := t.turns
+	t.turns++
+
+	t.relationship.Trust += i.RelationshipUpdate.Trust
+	t.relationship.Respect += i.RelationshipUpdate.Respect
+	t.relationship.Familiar += i.RelationshipUpdate.Familiar
+	t.relationship.Flirtatious += i.RelationshipUpdate.Flirtatious
+	t.relationship.Attraction += i.RelationshipUpdate.Attraction
+
+	if w, ok := strengthWeights[i.Emotion.Strength]; ok {
+		t.strengthSum += w
+		t.strengths++
+	}
+
+	code := i.Emotion.Behavior
+	if code == "" || code == SpaffCodeUnspecified {
+		return
+	}
+
+	if t.counts == nil {
+		t.counts = map[SpaffCode]int{}
+	}
+	if t.counts[code] == 0 {
+		t.firstSeen = append(t.firstSeen, code)
+	}
+	t.counts[code]++
+
+	if t.last != "" && t.last != code {
+		t.transitions = append(t.transitions, EmotionTransition{Turn: turn, From: t.last, To: code})
+	}
+	t.last = code
+}
+
+// Summary returns the summary of the interactions observed so far.
+func (t *EmotionTracker) Summary() EmotionSummary {
+	s := EmotionSummary{
+		Turns:        t.turns,
+		Counts:       maps.Clone(t.counts),
+		Transitions:  slices.Clone(t.transitions),
+		Relationship: t.relationship,
+	}
+
+	for _, code := range t.firstSeen {
+		if t.counts[code] > s.Counts[s.Dominant] {
+			s.Dominant = code
+		}
+	}
+
+	if t.strengths > 0 {
+		s.AverageStrength = float64(t.strengthSum) / float64(t.strengths)
+	}
+
+	return s
+}
@@ -0,0 +1,65 @@
+package inworld
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEmotionTracker(t *testing.T) {
+	script := []Interaction{
+		{Emotion: Emotion{Behavior: ScaffCodeJoy, Strength: StrengthStrong}, RelationshipUpdate: RelationshipUpdate{Trust: 1}},
+		{Emotion: Emotion{Behavior: ScaffCodeJoy, Strength: StrengthWeak}},
+		// An error response, which is counted as a turn only.
+		{Emotion: Emotion{Behavior: SpaffCodeUnspecified, Strength: StrengthUnspecified}, RelationshipUpdate: RelationshipUpdate{Respect: 2}},
+		{Emotion: Emotion{Behavior: ScaffCodeAnger, Strength: StrengthNormal}, RelationshipUpdate: RelationshipUpdate{Trust: -2}},
+		{Emotion: Emotion{Behavior: ScaffCodeAnger, Strength: StrengthStrong}, RelationshipUpdate: RelationshipUpdate{Familiar: 1}},
+		{Emotion: Emotion{Behavior: ScaffCodeJoy, Strength: StrengthNormal}},
+	}
+
+	var tracker EmotionTracker
+	for _, i := range script {
+		tracker.Observe(i)
+	}
+
+	want := EmotionSummary{
+		Turns:    6,
+		Dominant: ScaffCodeJoy,
+		Counts:   map[SpaffCode]int{ScaffCodeJoy: 3, ScaffCodeAnger: 2},
+		Transitions: []EmotionTransition{
+			{Turn: 3, From: ScaffCodeJoy, To: ScaffCodeAnger},
+			{Turn: 5, From: ScaffCodeAnger, To: ScaffCodeJoy},
+		},
+		AverageStrength: 11.0 / 5,
+		Relationship:    RelationshipUpdate{Trust: -1, Respect: 2, Familiar: 1},
+	}
+
+	got := tracker.Summary()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// The summary doesn't share the memory of the tracker.
+	got.Counts[ScaffCodeJoy] = 0
+	got.Transitions[0].To = ScaffCodeSadness
+	if again := tracker.Summary(); !reflect.DeepEqual(again, want) {
+		t.Errorf("summary is changed to %+v", again)
+	}
+}
+
+func TestEmotionTrackerTie(t *testing.T) {
+	var tracker EmotionTracker
+	tracker.Observe(Interaction{Emotion: Emotion{Behavior: ScaffCodeSadness}})
+	tracker.Observe(Interaction{Emotion: Emotion{Behavior: ScaffCodeHumor}})
+
+	// The earliest emotion wins a tie.
+	if got := tracker.Summary().Dominant; got != ScaffCodeSadness {
+		t.Errorf("dominant is %s, want %s", got, ScaffCodeSadness)
+	}
+}
+
+func TestEmotionTrackerZero(t *testing.T) {
+	var tracker EmotionTracker
+	if got := tracker.Summary(); !reflect.DeepEqual(got, EmotionSummary{}) {
+		t.Errorf("got %+v, want zero summary", got)
+	}
+}
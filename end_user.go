@@ -0,0 +1,64 @@
+package inworld
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Gender of the end user, see EndUserConfig.Gender.
+type Gender string
+
+const (
+	GenderMale      Gender = "male"
+	GenderFemale    Gender = "female"
+	GenderNonBinary Gender = "non-binary"
+)
+
+// Known reports whether the gender is one of the declared constants.
+func (g Gender) Known() bool {
+	switch g {
+	case GenderMale, GenderFemale, GenderNonBinary:
+		return true
+	default:
+		return false
+	}
+}
+
+// EndUserOption sets an optional field of the EndUserConfig built by
+// NewEndUser.
+type EndUserOption func(*EndUserConfig)
+
+// WithGender sets the gender of the end user.
+func WithGender(g Gender) EndUserOption {
+	return func(u *EndUserConfig) { u.Gender = string(g) }
+}
+
+// WithRole sets the role of the end user, e.g. "detective".
+func WithRole(role string) EndUserOption {
+	return func(u *EndUserConfig) { u.Role = role }
+}
+
+// WithAge sets the age of the end user.
+func WithAge(age int) EndUserOption {
+	return func(u *EndUserConfig) { u.Age = int64(age) }
+}
+
+// NewEndUser builds the configuration of the end user with the given id and
+// name, both of which may be empty. The age must not be negative, the gender
+// must be one of the declared constants. Zero age is not sent, as if it was not
+// set.
+func NewEndUser(id, name string, opts ...EndUserOption) (EndUserConfig, error) {
+	u := EndUserConfig{EndUserID: id, GivenName: name}
+	for _, opt := range opts {
+		opt(&u)
+	}
+
+	if u.Age < 0 {
+		return EndUserConfig{}, errors.Errorf("age must not be negative, got %d", u.Age)
+	}
+
+	if u.Gender != "" && !Gender(u.Gender).Known() {
+		return EndUserConfig{}, errors.Errorf("unknown gender %q", u.Gender)
+	}
+
+	return u, nil
+}
@@ -0,0 +1,57 @@
+package inworld
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestNewEndUserAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []EndUserOption
+		want    string
+		wantErr bool
+	}{
+		{name: "not set", want: `{"endUserId":"id","givenName":"Alex"}`},
+		{name: "zero", opts: []EndUserOption{WithAge(0)}, want: `{"endUserId":"id","givenName":"Alex"}`},
+		{name: "one", opts: []EndUserOption{WithAge(1)}, want: `{"endUserId":"id","givenName":"Alex","age":"1"}`},
+		{name: "regular", opts: []EndUserOption{WithAge(42)}, want: `{"endUserId":"id","givenName":"Alex","age":"42"}`},
+		{name: "max int32", opts: []EndUserOption{WithAge(math.MaxInt32)}, want: `{"endUserId":"id","givenName":"Alex","age":"2147483647"}`},
+		{name: "negative", opts: []EndUserOption{WithAge(-1)}, wantErr: true},
+		{name: "last wins", opts: []EndUserOption{WithAge(-1), WithAge(30)}, want: `{"endUserId":"id","givenName":"Alex","age":"30"}`},
+		{name: "with gender", opts: []EndUserOption{WithAge(7), WithGender(GenderNonBinary)}, want: `{"endUserId":"id","givenName":"Alex","gender":"non-binary","age":"7"}`},
+		{name: "unknown gender", opts: []EndUserOption{WithAge(7), WithGender("other")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := NewEndUser("id", "Alex", tt.opts...)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %+v, want an error", u)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("got %s, want %s", b, tt.want)
+			}
+
+			var decoded EndUserConfig
+			if err = json.Unmarshal(b, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if decoded != u {
+				t.Errorf("decoded %+v, want %+v", decoded, u)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package inworld
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestGRPCStatusTypedDetails(t *testing.T) {
+	var e Error
+	err := json.Unmarshal([]byte(`{
+		"code": 3,
+		"message": "invalid character",
+		"details": [
+			{
+				"@type": "type.googleapis.com/google.rpc.BadRequest",
+				"fieldViolations": [{"field": "defaultCharacterDescription.givenName", "description": "too long"}]
+			},
+			{
+				"@type": "type.googleapis.com/google.rpc.QuotaFailure",
+				"violations": [{"subject": "workspaces/w", "description": "too many characters"}]
+			}
+		]
+	}`), &e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := status.FromError(&e)
+	if !ok || s.Code() != codes.InvalidArgument || s.Message() != "invalid character" {
+		t.Fatalf("unexpected status %v", s)
+	}
+
+	details := s.Details()
+	if len(details) != 2 {
+		t.Fatalf("%d details, want 2: %v", len(details), details)
+	}
+
+	br, ok := details[0].(*errdetails.BadRequest)
+	if !ok || len(br.GetFieldViolations()) != 1 ||
+		br.GetFieldViolations()[0].GetField() != "defaultCharacterDescription.givenName" {
+		t.Errorf("unexpected first detail %T %v", details[0], details[0])
+	}
+
+	qf, ok := details[1].(*errdetails.QuotaFailure)
+	if !ok || len(qf.GetViolations()) != 1 || qf.GetViolations()[0].GetSubject() != "workspaces/w" {
+		t.Errorf("unexpected second detail %T %v", details[1], details[1])
+	}
+}
+
+func TestGRPCStatusUntypedDetails(t *testing.T) {
+	e := &Error{Code: codes.Internal, Details: []interface{}{map[string]interface{}{"reason": "unknown"}}}
+
+	details := e.GRPCStatus().Details()
+	if len(details) != 1 {
+		t.Fatalf("%d details, want 1", len(details))
+	}
+
+	v, ok := details[0].(*structpb.Value)
+	if !ok || v.GetStructValue().GetFields()["reason"].GetStringValue() != "unknown" {
+		t.Errorf("unexpected detail %T %v", details[0], details[0])
+	}
+}
+
+func TestGRPCStatusDetailThatCantBePacked(t *testing.T) {
+	e := &Error{
+		Code:    codes.Internal,
+		Message: "internal",
+		Details: []interface{}{
+			map[string]interface{}{"reason": "kept"},
+			make(chan int),
+			map[string]interface{}{
+				"@type":    "type.googleapis.com/google.rpc.ErrorInfo",
+				"reason":   "ALSO_KEPT",
+				"metadata": map[string]interface{}{},
+			},
+		},
+	}
+
+	details := e.GRPCStatus().Details()
+	if len(details) != 3 {
+		t.Fatalf("%d details, want 3: %v", len(details), details)
+	}
+
+	if _, ok := details[0].(*structpb.Value); !ok {
+		t.Errorf("first detail is %T, want *structpb.Value", details[0])
+	}
+
+	info, ok := details[1].(*errdetails.DebugInfo)
+	if !ok || !strings.Contains(info.GetDetail(), "detail 1 can't be packed") {
+		t.Errorf("second detail is %T %v, want the DebugInfo placeholder", details[1], details[1])
+	}
+
+	if ei, ok := details[2].(*errdetails.ErrorInfo); !ok || ei.GetReason() != "ALSO_KEPT" {
+		t.Errorf("third detail is %T %v, want ErrorInfo", details[2], details[2])
+	}
+}
@@ -0,0 +1,41 @@
+package inworld
+
+import (
+	"context"
+	stderrors "errors"
+)
+
+// GetCharacterOK is like GetCharacter, but reports a missing character with
+// false instead of ErrNotFound.
+func (c Client) GetCharacterOK(
+	ctx context.Context,
+	characterName string,
+	view CharacterItemView,
+) (Character, bool, error) {
+	return found(c.GetCharacter(ctx, characterName, view))
+}
+
+// GetSceneOK is like GetScene, but reports a missing scene with false instead
+// of ErrNotFound.
+func (c Client) GetSceneOK(ctx context.Context, sceneID string, view SceneItemView) (Scene, bool, error) {
+	return found(c.GetScene(ctx, sceneID, view))
+}
+
+// GetCommonKnowledgeOK is like GetCommonKnowledge, but reports a missing
+// common knowledge with false instead of ErrNotFound.
+func (c Client) GetCommonKnowledgeOK(ctx context.Context, commonKnowledgeID string) (CommonKnowledge, bool, error) {
+	return found(c.GetCommonKnowledge(ctx, commonKnowledgeID))
+}
+
+// found converts ErrNotFound to false, other errors are returned as is.
+func found[T any](v T, err error) (T, bool, error) {
+	var zero T
+	switch {
+	case err == nil:
+		return v, true, nil
+	case stderrors.Is(err, ErrNotFound):
+		return zero, false, nil
+	default:
+		return zero, false, err
+	}
+}
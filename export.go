@@ -0,0 +1,160 @@
+package inworld
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ExportCharacter returns the character with meta in the format read by
+// ParseCharacterExport. The output is deterministic (fields are in the order of
+// declaration, map keys are sorted, indentation is two spaces), so it is
+// suitable for keeping in version control. Fields managed by the server are
+// included for reference.
+func (c Client) ExportCharacter(ctx context.Context, characterName string) ([]byte, error) {
+	var b bytes.Buffer
+	if err := c.ExportCharacterTo(ctx, &b, characterName); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// ExportCharacterTo writes the character exported by ExportCharacter to w.
+func (c Client) ExportCharacterTo(ctx context.Context, w io.Writer, characterName string) error {
+	ch, err := c.GetCharacter(ctx, characterName, CharacterItemViewWithMeta)
+	if err != nil {
+		return err
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	e.SetEscapeHTML(false)
+	return errors.Wrap(e.Encode(ch), "writing character export")
+}
+
+// ParseCharacterExport reads a character exported from Inworld Studio or with
+// ExportCharacter and returns it ready to be passed to CreateCharacter: the
+// fields managed by the server are cleared, see Character.Diff. Both the bare
+// character and the character wrapped into the "character" field (as done by
+// some versions of the export) are accepted, unknown fields are ignored.
+// References to common knowledge are kept as is, so they must exist in the
+// workspace the character is imported to.
+func ParseCharacterExport(r io.Reader) (Character, error) {
+	var export struct {
+		Character *Character `json:"character"`
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return Character{}, errors.Wrap(err, "reading character export")
+	}
+
+	if err = json.Unmarshal(b, &export); err != nil {
+		return Character{}, errors.Wrap(err, "json unmarshaling character export")
+	}
+
+	ch := export.Character
+	if ch == nil {
+		ch = new(Character)
+		if err = json.Unmarshal(b, ch); err != nil {
+			return Character{}, errors.Wrap(err, "json unmarshaling character export")
+		}
+	}
+
+	return ch.withoutServerFields(), nil
+}
+
+// ImportCharacter creates the character read by ParseCharacterExport in the
+// workspace. Like CreateCharacter, the character must be deployed afterwards.
+func (c Client) ImportCharacter(ctx context.Context, workspaceID string, r io.Reader) (Character, error) {
+	ch, err := ParseCharacterExport(r)
+	if err != nil {
+		return Character{}, err
+	}
+
+	return c.CreateCharacter(ctx, workspaceID, ch)
+}
+
+// ExportWorkspaceCharacters writes all characters of the workspace to w as
+// JSON lines, one character per line, as they are listed. It returns the
+// number of written characters.
+func (c Client) ExportWorkspaceCharacters(ctx context.Context, workspaceID string, w io.Writer) (int, error) {
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	e := json.NewEncoder(w)
+	e.SetEscapeHTML(false)
+	err = c.RangeCharacters(ctx, GetCharactersRequest{WorkspaceID: workspaceID}, func(ch Character) error {
+		if err := e.Encode(ch); err != nil {
+			return errors.Wrap(err, "writing character")
+		}
+		n++
+		return nil
+	})
+
+	return n, err
+}
+
+// ImportWorkspaceCharacters reads JSON lines written by
+// ExportWorkspaceCharacters and creates the characters in the workspace
+// running up to concurrency creations at once. The characters are read as they
+// are created, so the input isn't buffered. Fields managed by the server are
+// cleared like ParseCharacterExport does. Created characters are returned in
+// the order of the input, a zero Character stands for each failed one. The
+// returned error joins the errors of all failed creations, reading stops at
+// the first malformed line.
+func (c Client) ImportWorkspaceCharacters(
+	ctx context.Context,
+	workspaceID string,
+	r io.Reader,
+	concurrency int,
+) ([]Character, error) {
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu  sync.Mutex
+		res []Character
+	)
+
+	d := json.NewDecoder(r)
+	err = runStream(ctx, concurrency, func() (func() error, bool, error) {
+		var ch Character
+		if err := d.Decode(&ch); err != nil {
+			if stderrors.Is(err, io.EOF) {
+				return nil, false, nil
+			}
+			return nil, false, errors.Wrapf(err, "reading character %d", len(res))
+		}
+
+		mu.Lock()
+		i := len(res)
+		res = append(res, Character{})
+		mu.Unlock()
+
+		return func() error {
+			created, err := c.CreateCharacter(ctx, workspaceID, ch.withoutServerFields())
+			if err != nil {
+				return errors.Wrapf(err, "creating character %d", i)
+			}
+
+			mu.Lock()
+			res[i] = created
+			mu.Unlock()
+			return nil
+		}, true, nil
+	})
+
+	return res, err
+}
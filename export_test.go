@@ -0,0 +1,78 @@
+package inworld
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExportImportWorkspaceCharacters(t *testing.T) {
+	stored := []Character{
+		{Name: "workspaces/src/characters/ada", DefaultCharacterDescription: CharacterDescription{GivenName: "Ada"}},
+		{Name: "workspaces/src/characters/bob", DefaultCharacterDescription: CharacterDescription{GivenName: "Bob"}},
+	}
+
+	var (
+		mu      sync.Mutex
+		created []string
+	)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/studio/v1/workspaces/src/characters":
+			_ = json.NewEncoder(w).Encode(GetCharactersResponse{Characters: stored})
+		case r.Method == http.MethodPost && r.URL.Path == "/studio/v1/workspaces/dst/characters":
+			var ch Character
+			if err := json.NewDecoder(r.Body).Decode(&ch); err != nil {
+				t.Error(err)
+				return
+			}
+			if ch.Name != "" {
+				t.Errorf("name %q is sent", ch.Name)
+			}
+
+			mu.Lock()
+			created = append(created, ch.DefaultCharacterDescription.GivenName)
+			mu.Unlock()
+
+			ch.Name = "workspaces/dst/characters/" + strings.ToLower(ch.DefaultCharacterDescription.GivenName)
+			_ = json.NewEncoder(w).Encode(ch)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+
+	var b bytes.Buffer
+	n, err := c.ExportWorkspaceCharacters(ctx, "src", &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(stored) || strings.Count(b.String(), "\n") != len(stored) {
+		t.Fatalf("exported %d characters:\n%s", n, b.String())
+	}
+
+	// Reading stops at the malformed line, the characters after it are not
+	// created.
+	b.WriteString("{\"name\": \n")
+	b.WriteString(`{"defaultCharacterDescription":{"givenName":"Eve"}}` + "\n")
+
+	res, err := c.ImportWorkspaceCharacters(ctx, "dst", &b, 2)
+	if err == nil || !strings.Contains(err.Error(), "reading character 2") {
+		t.Errorf("error is %v, want one about character 2", err)
+	}
+
+	if len(res) != 2 || res[0].Name != "workspaces/dst/characters/ada" || res[1].Name != "workspaces/dst/characters/bob" {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	slices.Sort(created)
+	if !slices.Equal(created, []string{"Ada", "Bob"}) {
+		t.Errorf("created %v, want [Ada Bob]", created)
+	}
+}
@@ -0,0 +1,108 @@
+package inworld
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Filter is an AIP-160 filter expression for GetCharactersRequest.Filter. Only
+// filtering by the full resource name combined with OR is documented, other
+// fields follow AIP-160 naming and are rejected by the server with
+// ErrInvalidArgument if it doesn't support them. The server rejects filters by
+// name combined with AND or with filters by other fields, FilterAnd and
+// FilterOr return an error for such combinations.
+type Filter string
+
+// String returns the filter expression.
+func (f Filter) String() string { return string(f) }
+
+// FilterByName matches the character with the resource name. Format:
+// workspaces/{workspace}/characters/{character}
+func FilterByName(characterName string) Filter {
+	return Filter("character.name=" + characterName)
+}
+
+// FilterByDisplayName matches characters with the given name, see
+// CharacterDescription.GivenName.
+func FilterByDisplayName(givenName string) Filter {
+	return Filter("character.default_character_description.given_name=" + strconv.Quote(givenName))
+}
+
+// FilterByTag matches characters having the user tag with the given name.
+func FilterByTag(tag string) Filter {
+	return Filter("character.user_tags:" + strconv.Quote(tag))
+}
+
+// FilterUpdatedAfter matches characters updated after t.
+func FilterUpdatedAfter(t time.Time) Filter {
+	return Filter("character.update_time>" + strconv.Quote(t.UTC().Format(time.RFC3339)))
+}
+
+// FilterAnd matches characters matching all the filters. Empty filters are
+// skipped. Filters by name can't be combined with AND.
+func FilterAnd(filters ...Filter) (Filter, error) { return combineFilters("AND", filters) }
+
+// FilterOr matches characters matching any of the filters. Empty filters are
+// skipped. Filters by name can be combined only with each other.
+func FilterOr(filters ...Filter) (Filter, error) { return combineFilters("OR", filters) }
+
+func combineFilters(op string, filters []Filter) (Filter, error) {
+	parts := make([]string, 0, len(filters))
+	for _, f := range filters {
+		if f != "" {
+			parts = append(parts, string(f))
+		}
+	}
+
+	if len(parts) == 1 {
+		return Filter(parts[0]), nil
+	}
+
+	if err := checkCombination(op, parts); err != nil {
+		return "", err
+	}
+
+	// Compound expressions are parenthesized, simple ones are kept as is to
+	// match the documented format: character.name=a OR character.name=b.
+	for i, p := range parts {
+		if strings.Contains(p, " AND ") || strings.Contains(p, " OR ") {
+			parts[i] = "(" + p + ")"
+		}
+	}
+
+	return Filter(strings.Join(parts, " "+op+" ")), nil
+}
+
+var (
+	filterQuoted = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	filterField  = regexp.MustCompile(`character\.[a-z_.]+`)
+)
+
+// checkCombination checks that the server accepts the parts of the filter
+// combined with the operator.
+func checkCombination(op string, parts []string) error {
+	var byName, byOther bool
+	for _, p := range parts {
+		// Quoted values may look like fields.
+		for _, field := range filterField.FindAllString(filterQuoted.ReplaceAllString(p, `""`), -1) {
+			if field == "character.name" {
+				byName = true
+			} else {
+				byOther = true
+			}
+		}
+	}
+
+	switch {
+	case byName && op == "AND":
+		return errors.New("filters by name can't be combined with AND")
+	case byName && byOther:
+		return errors.New("filters by name can't be combined with filters by other fields")
+	default:
+		return nil
+	}
+}
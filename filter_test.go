@@ -0,0 +1,84 @@
+package inworld
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCombineFilters(t *testing.T) {
+	a := FilterByName("workspaces/w/characters/a")
+	b := FilterByName("workspaces/w/characters/b")
+	tag := FilterByTag("hero")
+	updated := FilterUpdatedAfter(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		combine func(...Filter) (Filter, error)
+		filters []Filter
+		want    Filter
+		wantErr bool
+	}{
+		{
+			name:    "names with or",
+			combine: FilterOr,
+			filters: []Filter{a, b},
+			want:    "character.name=workspaces/w/characters/a OR character.name=workspaces/w/characters/b",
+		},
+		{name: "single name with and", combine: FilterAnd, filters: []Filter{a, ""}, want: a},
+		{name: "nothing", combine: FilterAnd, want: ""},
+		{
+			name:    "other fields with and",
+			combine: FilterAnd,
+			filters: []Filter{tag, updated},
+			want:    `character.user_tags:"hero" AND character.update_time>"2024-01-02T03:04:05Z"`,
+		},
+		{
+			name:    "other fields with or",
+			combine: FilterOr,
+			filters: []Filter{tag, FilterByDisplayName("Ada")},
+			want:    `character.user_tags:"hero" OR character.default_character_description.given_name="Ada"`,
+		},
+		{
+			name:    "nested",
+			combine: FilterAnd,
+			filters: []Filter{mustFilter(FilterOr(tag, FilterByTag("villain"))), updated},
+			want:    `(character.user_tags:"hero" OR character.user_tags:"villain") AND character.update_time>"2024-01-02T03:04:05Z"`,
+		},
+		{
+			name:    "quoted value looking like name",
+			combine: FilterAnd,
+			filters: []Filter{FilterByDisplayName("character.name"), tag},
+			want:    `character.default_character_description.given_name="character.name" AND character.user_tags:"hero"`,
+		},
+		{name: "names with and", combine: FilterAnd, filters: []Filter{a, b}, wantErr: true},
+		{name: "name and tag with and", combine: FilterAnd, filters: []Filter{a, tag}, wantErr: true},
+		{name: "name and tag with or", combine: FilterOr, filters: []Filter{a, tag}, wantErr: true},
+		{name: "nested names with and", combine: FilterAnd, filters: []Filter{mustFilter(FilterOr(a, b)), updated}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.combine(tt.filters...)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %q, want an error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustFilter(f Filter, err error) Filter {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
@@ -5,11 +5,10 @@ go 1.21
 require (
 	github.com/golang/protobuf v1.5.3
 	github.com/pkg/errors v0.9.1
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240123012728-ef4313101c80
 	google.golang.org/grpc v1.62.0
 	google.golang.org/protobuf v1.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	golang.org/x/sys v0.16.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240123012728-ef4313101c80 // indirect
-)
+require golang.org/x/sys v0.16.0 // indirect
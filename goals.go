@@ -0,0 +1,95 @@
+package inworld
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// GoalsConfig is the typed representation of Character.YamlConfig used for
+// defining goals and actions v2. For more details:
+// https://docs.inworld.ai/docs/tutorial-basics/goals/
+type GoalsConfig struct {
+	// Intents recognized in the player's messages.
+	Intents []Intent `yaml:"intents,omitempty"`
+	// Goals of the character.
+	Goals []Goal `yaml:"goals,omitempty"`
+}
+
+// Intent is a set of phrases expressing the same intention of the player.
+type Intent struct {
+	Name            string   `yaml:"name"`
+	TrainingPhrases []string `yaml:"training_phrases,omitempty"`
+}
+
+// Goal describes when the character performs the actions.
+type Goal struct {
+	Name string `yaml:"name"`
+	// Whether the goal can be activated more than once.
+	Repeatable bool           `yaml:"repeatable,omitempty"`
+	Activation GoalActivation `yaml:"activation,omitempty"`
+	Actions    []GoalAction   `yaml:"actions,omitempty"`
+}
+
+// GoalActivation describes what activates the goal, an intent of the player or
+// a trigger.
+type GoalActivation struct {
+	Intent  string `yaml:"intent,omitempty"`
+	Trigger string `yaml:"trigger,omitempty"`
+}
+
+// GoalAction is an action performed by the character when the goal is
+// activated.
+type GoalAction struct {
+	// Instruction for the character on what to say or do.
+	Instruction string `yaml:"instruction,omitempty"`
+	// Text the character says word for word.
+	SayVerbatim string `yaml:"say_verbatim,omitempty"`
+	// Emotion the character changes to, see SpaffCode.
+	EmotionChange string `yaml:"emotion_change,omitempty"`
+	// Trigger sent to the client.
+	SendTrigger string `yaml:"send_trigger,omitempty"`
+	// Names of the goals deactivated by the action.
+	RevokeGoals []string `yaml:"revoke_goals,omitempty"`
+}
+
+// ParseYamlConfig parses Character.YamlConfig, errors contain the line of the
+// problem. GoalsConfig is written by hand after the documentation, which
+// doesn't describe the schema in full, so unknown fields are ignored rather
+// than rejected. They are lost when the config is marshaled back.
+func ParseYamlConfig(config string) (GoalsConfig, error) {
+	var cfg GoalsConfig
+
+	if err := yaml.NewDecoder(strings.NewReader(config)).Decode(&cfg); err != nil && !stderrors.Is(err, io.EOF) {
+		return GoalsConfig{}, errors.Wrap(err, "parsing yaml config")
+	}
+
+	return cfg, nil
+}
+
+// MarshalYamlConfig encodes the config to be set to Character.YamlConfig.
+func MarshalYamlConfig(cfg GoalsConfig) (string, error) {
+	var b bytes.Buffer
+
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+	if err := e.Encode(cfg); err != nil {
+		return "", errors.Wrap(err, "marshaling yaml config")
+	}
+	if err := e.Close(); err != nil {
+		return "", errors.Wrap(err, "marshaling yaml config")
+	}
+
+	return b.String(), nil
+}
+
+// ValidateYamlConfig checks that the config is a well-formed goals config, see
+// ParseYamlConfig. Empty config is valid.
+func ValidateYamlConfig(config string) error {
+	_, err := ParseYamlConfig(config)
+	return err
+}
@@ -0,0 +1,57 @@
+package inworld
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseYamlConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		config  string
+		want    int // number of goals
+		wantErr string
+	}{
+		{name: "empty"},
+		{
+			name: "goals",
+			config: `goals:
+  - name: greet
+    activation:
+      trigger: greet
+    actions:
+      - say_verbatim: Hello!
+`,
+			want: 1,
+		},
+		{
+			name: "unknown fields",
+			config: `goals:
+  - name: greet
+    priority: 1
+    actions:
+      - instruction: say hello
+        emotion: joy
+`,
+			want: 1,
+		},
+		{name: "malformed", config: "goals:\n  - name: [greet\n", wantErr: "line "},
+		{name: "wrong type", config: "goals: greet\n", wantErr: "line 1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseYamlConfig(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error is %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cfg.Goals) != tt.want {
+				t.Errorf("got %d goals, want %d", len(cfg.Goals), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package inworld
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// InteractionSegment is a part of the character response, either a spoken
+// line or a narrated action.
+type InteractionSegment struct {
+	Kind SegmentKind
+	// Text of the segment without narration markers.
+	Text string
+}
+
+// SegmentKind describes the kind of the InteractionSegment.
+type SegmentKind string
+
+const (
+	// SegmentKindSpeech represents a line spoken by the character.
+	SegmentKindSpeech SegmentKind = "SPEECH"
+	// SegmentKindNarration represents a narrated action, e.g. "*smiles*". These
+	// are returned only when CharacterDescription.NarrativeActionsEnabled is
+	// set.
+	SegmentKindNarration SegmentKind = "NARRATION"
+)
+
+// Segments splits TextList into spoken lines and narrated actions keeping the
+// original order. Narrated actions are the parts of the text wrapped in
+// asterisks, a single entry may mix both kinds, e.g.
+// "*smiles* Nice to meet you.". An unpaired asterisk is kept as a part of the
+// speech. Markers don't nest, each asterisk closes the narration opened by the
+// previous one, so "*a *b* c*" is narration "a", speech "b" and narration "c".
+func (i Interaction) Segments() []InteractionSegment {
+	var res []InteractionSegment
+	add := func(kind SegmentKind, text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			res = append(res, InteractionSegment{Kind: kind, Text: text})
+		}
+	}
+
+	for _, text := range i.TextList {
+		for {
+			start := strings.IndexByte(text, '*')
+			if start < 0 {
+				break
+			}
+
+			end := strings.IndexByte(text[start+1:], '*')
+			if end < 0 {
+				break
+			}
+			end += start + 1
+
+			add(SegmentKindSpeech, text[:start])
+			add(SegmentKindNarration, text[start+1:end])
+			text = text[end+1:]
+		}
+
+		add(SegmentKindSpeech, text)
+	}
+
+	return res
+}
+
+// TriggeredActions returns the actions of the character to be handled by the
+// game logic: the active triggers followed by the custom event, if any.
+func (i Interaction) TriggeredActions() []TriggerEvent {
+	res := slices.Clip(i.ActiveTriggers)
+	if i.CustomEvent.CustomEvent != "" {
+		res = append(res, TriggerEvent{
+			Trigger:    i.CustomEvent.CustomEvent,
+			Parameters: i.CustomEvent.Parameters,
+		})
+	}
+	return res
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers of the parameters are
+// decoded as json.Number to keep large integers intact.
+func (i *Interaction) UnmarshalJSON(b []byte) error {
+	type interaction Interaction
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return errors.Wrap(d.Decode((*interaction)(i)), "json unmarshaling interaction")
+}
+
+// IntParam returns the integer parameter of the interaction with the given
+// name.
+func (i Interaction) IntParam(name string) (int64, bool) {
+	n, ok := number(i.Parameters[name])
+	if !ok {
+		return 0, false
+	}
+
+	v, err := n.Int64()
+	return v, err == nil
+}
+
+// FloatParam returns the numeric parameter of the interaction with the given
+// name.
+func (i Interaction) FloatParam(name string) (float64, bool) {
+	n, ok := number(i.Parameters[name])
+	if !ok {
+		return 0, false
+	}
+
+	v, err := n.Float64()
+	return v, err == nil
+}
+
+// Usage holds usage information (e.g. consumed tokens or character-seconds)
+// of the interaction. There is no documentation for this object, so none of
+// its fields can be relied on, all of them are kept as returned by the server
+// and can be read with the accessors.
+type Usage struct {
+	// RawUsage holds all usage values. Numbers are decoded as json.Number.
+	RawUsage map[string]any
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *Usage) UnmarshalJSON(b []byte) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return errors.Wrap(d.Decode(&u.RawUsage), "json unmarshaling usage")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u Usage) MarshalJSON() ([]byte, error) { return json.Marshal(u.RawUsage) }
+
+// Int returns the integer usage value with the given name.
+func (u Usage) Int(name string) (int64, bool) {
+	n, ok := number(u.RawUsage[name])
+	if !ok {
+		return 0, false
+	}
+
+	v, err := n.Int64()
+	return v, err == nil
+}
+
+// Float returns the numeric usage value with the given name.
+func (u Usage) Float(name string) (float64, bool) {
+	n, ok := number(u.RawUsage[name])
+	if !ok {
+		return 0, false
+	}
+
+	v, err := n.Float64()
+	return v, err == nil
+}
+
+// number converts decoded JSON value to json.Number. 64-bit integers are
+// encoded as strings by the API, so numeric strings are accepted too.
+func number(v any) (json.Number, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		return v, true
+	case string:
+		return json.Number(v), true
+	default:
+		return "", false
+	}
+}
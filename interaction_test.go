@@ -0,0 +1,235 @@
+package inworld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestInteractionParamsKeepLargeIntegers(t *testing.T) {
+	var i Interaction
+	err := json.Unmarshal([]byte(`{
+		"name": "workspaces/w/sessions/s/interactions/i",
+		"parameters": {
+			"itemId": 9007199254740993,
+			"stringId": "9223372036854775807",
+			"quantity": 3,
+			"ratio": 0.25,
+			"name": "sword"
+		}
+	}`), &i)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ints := map[string]int64{
+		"itemId":   9007199254740993, // 2^53 + 1 isn't representable as float64.
+		"stringId": 9223372036854775807,
+		"quantity": 3,
+	}
+	for name, want := range ints {
+		if got, ok := i.IntParam(name); !ok || got != want {
+			t.Errorf("IntParam(%q) = %d, %t, want %d", name, got, ok, want)
+		}
+	}
+
+	if got, ok := i.FloatParam("ratio"); !ok || got != 0.25 {
+		t.Errorf("FloatParam(ratio) = %v, %t", got, ok)
+	}
+
+	for _, name := range []string{"ratio", "name", "missing"} {
+		if _, ok := i.IntParam(name); ok {
+			t.Errorf("IntParam(%q) is ok", name)
+		}
+	}
+
+	if i.Name != "workspaces/w/sessions/s/interactions/i" {
+		t.Errorf("other fields are not decoded: %+v", i)
+	}
+}
+
+// triggerResponse is a response of SendTrigger as it is returned by the
+// server, when the trigger activates a goal sending a custom event.
+const triggerResponse = `{
+	"name": "workspaces/w/sessions/s/interactions/i",
+	"textList": ["Follow me, the treasure is this way."],
+	"emotion": {"behavior": "JOY", "strength": "STRONG"},
+	"sessionId": "s",
+	"relationshipUpdate": {"trust": 1},
+	"activeTriggers": [
+		{"trigger": "workspaces/w/triggers/show_map", "parameters": [{"name": "region", "value": "north"}]}
+	],
+	"customEvent": {
+		"customEvent": "workspaces/w/triggers/give_item",
+		"parameters": [{"name": "item", "value": "key"}, {"name": "count", "value": "1"}]
+	}
+}`
+
+func TestSendTriggerActions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req SendTriggerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		if req.TriggerEvent.Trigger != "workspaces/w/triggers/greet" {
+			t.Errorf("trigger is %q", req.TriggerEvent.Trigger)
+		}
+		_, _ = w.Write([]byte(triggerResponse))
+	})
+
+	i, err := c.SendTrigger(context.Background(), SendTriggerRequest{
+		SessionID:        "s",
+		SessionCharacter: "workspaces/w/sessions/s/sessionCharacters/c",
+		TriggerEvent:     TriggerEvent{Trigger: "greet"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if i.CustomEvent.CustomEvent != "workspaces/w/triggers/give_item" || len(i.CustomEvent.Parameters) != 2 {
+		t.Errorf("unexpected custom event %+v", i.CustomEvent)
+	}
+
+	actions := i.TriggeredActions()
+	want := []TriggerEvent{
+		{Trigger: "workspaces/w/triggers/show_map", Parameters: []Parameter{{Name: "region", Value: "north"}}},
+		{Trigger: "workspaces/w/triggers/give_item", Parameters: []Parameter{{Name: "item", Value: "key"}, {Name: "count", Value: "1"}}},
+	}
+	if !reflect.DeepEqual(actions, want) {
+		t.Fatalf("got %+v, want %+v", actions, want)
+	}
+	if v, ok := actions[1].Param("item"); !ok || v != "key" {
+		t.Errorf("item is %q, %v", v, ok)
+	}
+}
+
+func TestTriggeredActionsWithoutCustomEvent(t *testing.T) {
+	var i Interaction
+	if err := json.Unmarshal([]byte(`{"customEvent": {}}`), &i); err != nil {
+		t.Fatal(err)
+	}
+	if actions := i.TriggeredActions(); len(actions) != 0 {
+		t.Errorf("got %+v, want no actions", actions)
+	}
+}
+
+func TestInteractionSegments(t *testing.T) {
+	speech := func(text string) InteractionSegment { return InteractionSegment{Kind: SegmentKindSpeech, Text: text} }
+	narration := func(text string) InteractionSegment {
+		return InteractionSegment{Kind: SegmentKindNarration, Text: text}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		textList []string
+		want     []InteractionSegment
+	}{
+		{name: "empty"},
+		{
+			name:     "plain text",
+			textList: []string{"Hello there.", "How are you?"},
+			want:     []InteractionSegment{speech("Hello there."), speech("How are you?")},
+		},
+		{
+			name:     "narration only",
+			textList: []string{"*smiles*", " *waves* "},
+			want:     []InteractionSegment{narration("smiles"), narration("waves")},
+		},
+		{
+			name:     "mixed",
+			textList: []string{"*smiles* Nice to meet you. *bows slightly* I'm Ada."},
+			want: []InteractionSegment{
+				narration("smiles"),
+				speech("Nice to meet you."),
+				narration("bows slightly"),
+				speech("I'm Ada."),
+			},
+		},
+		{
+			name:     "unterminated",
+			textList: []string{"*sighs* Rated 5* by critics."},
+			want:     []InteractionSegment{narration("sighs"), speech("Rated 5* by critics.")},
+		},
+		{
+			name:     "nested",
+			textList: []string{"*a *b* c*"},
+			want:     []InteractionSegment{narration("a"), speech("b"), narration("c")},
+		},
+		{
+			name:     "empty markers",
+			textList: []string{"** Hi! *  *"},
+			want:     []InteractionSegment{speech("Hi!")},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Interaction{TextList: tt.textList}.Segments()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsage(t *testing.T) {
+	var i Interaction
+	err := json.Unmarshal([]byte(`{"usage": {
+		"inputTokens": 12,
+		"totalTokens": "9007199254740993",
+		"audioSeconds": 1.5,
+		"model": "fast",
+		"details": {"cached": 3}
+	}}`), &i)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.Usage == nil {
+		t.Fatal("usage is nil")
+	}
+
+	for _, tt := range []struct {
+		name      string
+		wantInt   int64
+		intOK     bool
+		wantFloat float64
+		floatOK   bool
+	}{
+		{name: "inputTokens", wantInt: 12, intOK: true, wantFloat: 12, floatOK: true},
+		{name: "totalTokens", wantInt: 9007199254740993, intOK: true, wantFloat: 9007199254740992, floatOK: true},
+		{name: "audioSeconds", wantFloat: 1.5, floatOK: true},
+		{name: "model"},
+		{name: "details"},
+		{name: "missing"},
+	} {
+		n, ok := i.Usage.Int(tt.name)
+		if n != tt.wantInt || ok != tt.intOK {
+			t.Errorf("Int(%q) = %d, %t, want %d, %t", tt.name, n, ok, tt.wantInt, tt.intOK)
+		}
+
+		f, ok := i.Usage.Float(tt.name)
+		if f != tt.wantFloat || ok != tt.floatOK {
+			t.Errorf("Float(%q) = %v, %t, want %v, %t", tt.name, f, ok, tt.wantFloat, tt.floatOK)
+		}
+	}
+
+	b, err := json.Marshal(i.Usage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"audioSeconds":1.5,"details":{"cached":3},"inputTokens":12,"model":"fast","totalTokens":"9007199254740993"}`
+	if string(b) != want {
+		t.Errorf("encoded as %s, want %s", b, want)
+	}
+}
+
+func TestInteractionWithoutUsage(t *testing.T) {
+	var i Interaction
+	if err := json.Unmarshal([]byte(`{"textList": ["Hi."]}`), &i); err != nil {
+		t.Fatal(err)
+	}
+	if i.Usage != nil {
+		t.Errorf("usage is %+v, want nil", i.Usage)
+	}
+}
@@ -0,0 +1,295 @@
+package inworld
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RangeCharacters calls f for each character matching the request, requesting
+// the pages one by one. Iteration stops at the first error returned by f or
+// by the server, and this error is returned. If the context is canceled, no
+// more pages are requested and the context error is returned.
+func (c Client) RangeCharacters(ctx context.Context, req GetCharactersRequest, f func(Character) error) error {
+	for {
+		// The context is checked before each page, so the cancellation error
+		// is returned as is instead of a wrapped transport error.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, err := c.GetCharacters(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, ch := range resp.Characters {
+			if err = f(ch); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextPageCursor.Empty() {
+			return nil
+		}
+
+		req.PageToken, req.Cursor = "", resp.NextPageCursor
+	}
+}
+
+// RangeScenes calls f for each scene matching the request, requesting the
+// pages one by one. Iteration stops at the first error returned by f or by the
+// server, and this error is returned. If the context is canceled, no more
+// pages are requested and the context error is returned.
+func (c Client) RangeScenes(ctx context.Context, req GetScenesRequest, f func(Scene) error) error {
+	for {
+		// The context is checked before each page, so the cancellation error
+		// is returned as is instead of a wrapped transport error.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, err := c.GetScenes(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, s := range resp.Scenes {
+			if err = f(s); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextPageCursor.Empty() {
+			return nil
+		}
+
+		req.PageToken, req.Cursor = "", resp.NextPageCursor
+	}
+}
+
+// RangeCommonKnowledge calls f for each common knowledge matching the request,
+// requesting the pages one by one, like RangeScenes does.
+func (c Client) RangeCommonKnowledge(
+	ctx context.Context,
+	req ListCommonKnowledgeRequest,
+	f func(CommonKnowledge) error,
+) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, err := c.ListCommonKnowledge(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, k := range resp.CommonKnowledge {
+			if err = f(k); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextPageCursor.Empty() {
+			return nil
+		}
+
+		req.PageToken, req.Cursor = "", resp.NextPageCursor
+	}
+}
+
+// GetCharacterScenes returns the scenes of the workspace the character is
+// referenced in, nil if there are none. The scenes can't be filtered by the
+// character on the server, so all scenes of the workspace are listed. Format of
+// the name: workspaces/{workspace}/characters/{character}
+func (c Client) GetCharacterScenes(ctx context.Context, characterName string) ([]Scene, error) {
+	workspaceID, err := resourceWorkspace(characterName, ResourceKindCharacter)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ListScenesContaining(ctx, workspaceID, characterName)
+}
+
+// ListScenesContaining returns the scenes of the workspace referencing the
+// character, nil if there are none. The character is either a resource name or
+// a bare id of a character of the workspace. The scenes can't be filtered on
+// the server, so every scene of the workspace is fetched page by page: the
+// call takes a request per page and is worth caching in large workspaces.
+func (c Client) ListScenesContaining(ctx context.Context, workspaceID, characterName string) ([]Scene, error) {
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if characterName == "" {
+		return nil, errors.New("character name is required")
+	}
+	if !strings.Contains(characterName, "/") {
+		characterName = "workspaces/" + workspaceID + "/characters/" + characterName
+	}
+
+	var res []Scene
+	err = c.RangeScenes(ctx, GetScenesRequest{WorkspaceID: workspaceID}, func(s Scene) error {
+		for _, ref := range s.Characters {
+			if ref.Character == characterName {
+				res = append(res, s)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListOrder is the order of the results of ListAllCharacters and
+// ListAllScenes. The API doesn't support ordering, so the results are sorted
+// on the client after all pages are fetched. Items equal by the ordering field
+// are ordered by the resource name. Items without the update time are placed
+// last when ordered by it, in both directions.
+type ListOrder string
+
+const (
+	// OrderUnspecified keeps the order returned by the server, which is
+	// unspecified and may change between calls.
+	OrderUnspecified       ListOrder = ""
+	OrderByName            ListOrder = "name"
+	OrderByNameDesc        ListOrder = "name desc"
+	OrderByDisplayName     ListOrder = "displayName"
+	OrderByDisplayNameDesc ListOrder = "displayName desc"
+	OrderByUpdateTime      ListOrder = "updateTime"
+	OrderByUpdateTimeDesc  ListOrder = "updateTime desc"
+)
+
+// ListAllCharacters returns all characters matching the request in the given
+// order, see ListOrder. Characters are ordered by display name using
+// CharacterDescription.GivenName.
+func (c Client) ListAllCharacters(ctx context.Context, req GetCharactersRequest, order ListOrder) ([]Character, error) {
+	if _, _, err := order.parse(); err != nil {
+		return nil, err
+	}
+
+	var res []Character
+	err := c.RangeCharacters(ctx, req, func(ch Character) error {
+		res = append(res, ch)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortList(res, order, func(ch Character) listKey {
+		return listKey{name: ch.Name, displayName: ch.DefaultCharacterDescription.GivenName, updateTime: ch.UpdateTime}
+	})
+	return res, nil
+}
+
+// ListAllScenes returns all scenes matching the request in the given order,
+// see ListOrder.
+func (c Client) ListAllScenes(ctx context.Context, req GetScenesRequest, order ListOrder) ([]Scene, error) {
+	if _, _, err := order.parse(); err != nil {
+		return nil, err
+	}
+
+	var res []Scene
+	err := c.RangeScenes(ctx, req, func(s Scene) error {
+		res = append(res, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortList(res, order, func(s Scene) listKey {
+		return listKey{name: s.Name, displayName: s.DisplayName, updateTime: s.UpdateTime}
+	})
+	return res, nil
+}
+
+// orderField is the field the results are ordered by, see ListOrder.
+type orderField int
+
+const (
+	orderFieldName orderField = iota
+	orderFieldDisplayName
+	orderFieldUpdateTime
+)
+
+func (o ListOrder) parse() (field orderField, desc bool, err error) {
+	switch o {
+	case OrderUnspecified, OrderByName:
+		return orderFieldName, false, nil
+	case OrderByNameDesc:
+		return orderFieldName, true, nil
+	case OrderByDisplayName:
+		return orderFieldDisplayName, false, nil
+	case OrderByDisplayNameDesc:
+		return orderFieldDisplayName, true, nil
+	case OrderByUpdateTime:
+		return orderFieldUpdateTime, false, nil
+	case OrderByUpdateTimeDesc:
+		return orderFieldUpdateTime, true, nil
+	default:
+		return 0, false, errors.Errorf("unknown order %q", o)
+	}
+}
+
+// listKey holds the fields of a listed item the results are ordered by.
+type listKey struct {
+	name, displayName string
+	updateTime        *time.Time
+}
+
+// sortList sorts the items in the valid order using the fields returned by key.
+func sortList[T any](items []T, order ListOrder, key func(T) listKey) {
+	if order == OrderUnspecified {
+		return
+	}
+
+	field, desc, _ := order.parse()
+	slices.SortStableFunc(items, func(a, b T) int {
+		ka, kb := key(a), key(b)
+
+		if field == orderFieldUpdateTime && (ka.updateTime == nil) != (kb.updateTime == nil) {
+			if ka.updateTime == nil {
+				return 1
+			}
+			return -1
+		}
+
+		res := 0
+		switch field {
+		case orderFieldDisplayName:
+			res = cmp.Compare(ka.displayName, kb.displayName)
+		case orderFieldUpdateTime:
+			if ka.updateTime != nil {
+				res = ka.updateTime.Compare(*kb.updateTime)
+			}
+		}
+		if res == 0 {
+			res = cmp.Compare(ka.name, kb.name)
+		}
+		if desc {
+			res = -res
+		}
+		return res
+	})
+}
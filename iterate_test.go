@@ -0,0 +1,109 @@
+package inworld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRangeStopsAfterCancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		iterate func(context.Context, Client, func()) error
+	}{
+		{
+			name: "characters",
+			page: `{"characters":[{"name":"workspaces/w/characters/a"},{"name":"workspaces/w/characters/b"}],"nextPageToken":"next"}`,
+			iterate: func(ctx context.Context, c Client, cancel func()) error {
+				return c.RangeCharacters(ctx, GetCharactersRequest{WorkspaceID: "w"}, func(Character) error {
+					cancel()
+					return nil
+				})
+			},
+		},
+		{
+			name: "scenes",
+			page: `{"scenes":[{"name":"workspaces/w/scenes/a"},{"name":"workspaces/w/scenes/b"}],"nextPageToken":"next"}`,
+			iterate: func(ctx context.Context, c Client, cancel func()) error {
+				return c.RangeScenes(ctx, GetScenesRequest{WorkspaceID: "w"}, func(Scene) error {
+					cancel()
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) > 1 {
+					t.Errorf("page %q is requested after the cancellation", r.URL.Query().Get("pageToken"))
+				}
+				_, _ = w.Write([]byte(tt.page))
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if err := tt.iterate(ctx, c, cancel); !errors.Is(err, context.Canceled) {
+				t.Errorf("got %v, want context.Canceled", err)
+			}
+			if n := requests.Load(); n != 1 {
+				t.Errorf("got %d requests, want 1", n)
+			}
+		})
+	}
+}
+
+func TestListAllScenesOrder(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"scenes":[
+			{"name":"workspaces/w/scenes/b","displayName":"Attic","updateTime":"2024-03-01T00:00:00Z"},
+			{"name":"workspaces/w/scenes/d","displayName":"Cellar"},
+			{"name":"workspaces/w/scenes/a","displayName":"Barn","updateTime":"2024-01-01T00:00:00Z"},
+			{"name":"workspaces/w/scenes/c","displayName":"Attic","updateTime":"2024-01-01T00:00:00Z"},
+			{"name":"workspaces/w/scenes/e","displayName":"Dock"}
+		]}`))
+	})
+
+	tests := []struct {
+		order ListOrder
+		want  []string
+	}{
+		{order: OrderUnspecified, want: []string{"b", "d", "a", "c", "e"}},
+		{order: OrderByName, want: []string{"a", "b", "c", "d", "e"}},
+		{order: OrderByNameDesc, want: []string{"e", "d", "c", "b", "a"}},
+		{order: OrderByDisplayName, want: []string{"b", "c", "a", "d", "e"}},
+		{order: OrderByDisplayNameDesc, want: []string{"e", "d", "a", "c", "b"}},
+		// Scenes without the update time are last, equal times are ordered by name.
+		{order: OrderByUpdateTime, want: []string{"a", "c", "b", "d", "e"}},
+		{order: OrderByUpdateTimeDesc, want: []string{"b", "c", "a", "e", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			scenes, err := c.ListAllScenes(context.Background(), GetScenesRequest{WorkspaceID: "w"}, tt.order)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, len(scenes))
+			for i, s := range scenes {
+				got[i] = path.Base(s.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := c.ListAllScenes(context.Background(), GetScenesRequest{WorkspaceID: "w"}, "createTime"); err == nil {
+		t.Error("unknown order is accepted")
+	}
+}
@@ -0,0 +1,144 @@
+package inworld
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// wireTypes are the types sent to or received from the API. Types of their
+// fields are checked too.
+var wireTypes = []any{
+	Character{},
+	Scene{},
+	CommonKnowledge{},
+	GetCharactersResponse{},
+	GetScenesResponse{},
+	ListCommonKnowledgeResponse{},
+	InteractionCountStat{},
+	StudioBaseVoice{},
+	Error{},
+	DeploymentResponse{},
+	CheckDeploymentStatusResponse{},
+	SimpleSendTextRequest{},
+	OpenSessionRequest{},
+	SendTextRequest{},
+	SendTriggerRequest{},
+	Session{},
+	Interaction{},
+	Tag{},
+	Workspace{},
+	Config{},
+}
+
+var jsonNamePattern = regexp.MustCompile(`^(-|@type|[a-z][A-Za-z0-9]*)$`)
+
+func TestJSONTags(t *testing.T) {
+	seen := map[reflect.Type]bool{}
+	for _, v := range wireTypes {
+		checkJSONTags(t, reflect.TypeOf(v), seen)
+	}
+}
+
+func checkJSONTags(t *testing.T, typ reflect.Type, seen map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ.PkgPath() != reflect.TypeOf(Client{}).PkgPath() || seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	names := map[string]string{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		where := typ.Name() + "." + f.Name
+
+		keys, err := tagKeys(f.Tag)
+		if err != nil {
+			t.Errorf("%s: malformed tag %q: %v", where, f.Tag, err)
+		}
+		for key, n := range keys {
+			if n > 1 {
+				t.Errorf("%s: %d %q keys in tag %q", where, n, key, f.Tag)
+			}
+			if key != "json" && key != "yaml" {
+				t.Errorf("%s: unexpected key %q in tag %q", where, key, f.Tag)
+			}
+		}
+
+		checkJSONTags(t, f.Type, seen)
+
+		tag, ok := f.Tag.Lookup("json")
+		if !ok {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if !jsonNamePattern.MatchString(name) {
+			t.Errorf("%s: json name %q is not lower camel case", where, name)
+		}
+		if prev, ok := names[name]; ok && name != "-" {
+			t.Errorf("%s: json name %q is used by %s too", where, name, prev)
+		}
+		names[name] = f.Name
+
+		if !strings.Contains(tag, ",") {
+			continue
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				// encoding/json never omits struct values, such fields must be
+				// pointers to be omitted.
+				if f.Type.Kind() == reflect.Struct {
+					t.Errorf("%s: omitempty has no effect on struct value of type %s", where, f.Type)
+				}
+			case "string":
+			case "":
+				t.Errorf("%s: empty option in json tag %q", where, tag)
+			default:
+				t.Errorf("%s: unknown option %q in json tag %q", where, opt, tag)
+			}
+		}
+	}
+}
+
+// tagKeys counts the keys of the tag parsed like reflect.StructTag.Lookup does.
+func tagKeys(tag reflect.StructTag) (map[string]int, error) {
+	keys := map[string]int{}
+	s := strings.TrimLeft(string(tag), " ")
+	for s != "" {
+		i := strings.Index(s, ":")
+		if i <= 0 || i+1 >= len(s) || s[i+1] != '"' {
+			return keys, errors.New(`expected key:"value" pairs`)
+		}
+		key := s[:i]
+
+		value, err := strconv.QuotedPrefix(s[i+1:])
+		if err != nil {
+			return keys, err
+		}
+
+		keys[key]++
+		s = strings.TrimLeft(s[i+1+len(value):], " ")
+	}
+	return keys, nil
+}
+
+func TestTagKeys(t *testing.T) {
+	keys, err := tagKeys(`json:"motivation" json:"motivation,omitempty"`)
+	if err != nil || keys["json"] != 2 {
+		t.Errorf("duplicate keys are not counted: %v, %v", keys, err)
+	}
+
+	if _, err = tagKeys(`json:motivation`); err == nil {
+		t.Error("unquoted value is accepted")
+	}
+}
@@ -0,0 +1,45 @@
+package inworld
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+type metadataKey struct{}
+
+type metadataPair struct{ key, value string }
+
+// WithMetadata returns a copy of ctx carrying the gRPC metadata pair sent with
+// requests made with this context as the Grpc-Metadata-<key> header, see
+// WithMetadataHeaders. Calling it again with the same key adds another value.
+// The headers managed by the client (the studio key type and the session id)
+// can't be overridden this way, such pairs are ignored.
+func WithMetadata(ctx context.Context, key, value string) context.Context {
+	pairs, _ := ctx.Value(metadataKey{}).([]metadataPair)
+	return context.WithValue(ctx, metadataKey{}, append(slices.Clip(pairs), metadataPair{key, value}))
+}
+
+// setMetadata adds the metadata pairs of the request context to its headers.
+func (c Client) setMetadata(r *http.Request) {
+	pairs, _ := r.Context().Value(metadataKey{}).([]metadataPair)
+	if len(pairs) == 0 {
+		return
+	}
+
+	bearerType, sessionID := c.metadataHeaders.names()
+	reserved := map[string]bool{
+		defaultBearerTypeHeader:             true,
+		defaultSessionIDHeader:              true,
+		http.CanonicalHeaderKey(bearerType): true,
+		http.CanonicalHeaderKey(sessionID):  true,
+		"Grpc-Metadata-Authorization":       true,
+	}
+
+	for _, p := range pairs {
+		h := http.CanonicalHeaderKey("Grpc-Metadata-" + p.key)
+		if !reserved[h] {
+			r.Header.Add(h, p.value)
+		}
+	}
+}
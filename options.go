@@ -0,0 +1,184 @@
+package inworld
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures optional settings of the Client, see NewClient.
+type Option func(*Client)
+
+// WithMaxResponseBytes limits the size of response bodies. Requests with larger
+// responses fail with ErrResponseTooLarge. Successful responses are decoded as
+// they are read, so the limit also bounds the memory used for large lists. A
+// non-positive value means no limit, which is the default.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *Client) { c.maxResponseBytes = n }
+}
+
+// WithMaxErrorBodyBytes limits the size of the error response bodies read to
+// decode the error, the rest of the body is discarded. A non-positive value
+// means DefaultMaxErrorBodyBytes. Unlike WithMaxResponseBytes, the limit is
+// always applied, so a misbehaving server can't exhaust the memory with a
+// huge error.
+func WithMaxErrorBodyBytes(n int64) Option {
+	return func(c *Client) { c.maxErrorBodyBytes = n }
+}
+
+// WithStudioBearer authorizes studio API requests with "Authorization: Bearer
+// <token>" instead of the Basic scheme with the studio API key.
+func WithStudioBearer(token string) Option {
+	return WithStudioCredentials(AuthSchemeBearer, StaticCredentials(token))
+}
+
+// WithStudioBasicCredentials authorizes studio API requests with the key and
+// the secret of the studio API key as they are shown in Studio, encoding them
+// into "Authorization: Basic base64(key:secret)". It is an alternative to the
+// studio API key passed to NewClient, which must be already encoded.
+func WithStudioBasicCredentials(key, secret string) Option {
+	return WithStudioCredentials(AuthSchemeBasic, StaticCredentials(basicCredentials(key, secret)))
+}
+
+// WithStudioTokenSource authorizes studio API requests with a bearer token
+// returned by ts for each request. It allows using short-lived scoped tokens
+// instead of the studio API key.
+func WithStudioTokenSource(ts TokenSource) Option {
+	return func(c *Client) { c.studioAuth = credentials{scheme: AuthSchemeBearer, src: ts} }
+}
+
+// WithStudioCredentials authorizes studio API requests with the credentials
+// provided by src. Credentials are cached until they expire, see
+// CachedCredentials.
+func WithStudioCredentials(scheme AuthScheme, src CredentialSource) Option {
+	return func(c *Client) {
+		c.studioAuth = credentials{scheme: scheme, src: CachedCredentials(src, DefaultExpiryMargin)}
+	}
+}
+
+// WithSimpleCredentials authorizes simple API requests with the credentials
+// provided by src. Credentials are cached until they expire, see
+// CachedCredentials.
+func WithSimpleCredentials(scheme AuthScheme, src CredentialSource) Option {
+	return func(c *Client) {
+		c.simpleAuth = credentials{scheme: scheme, src: CachedCredentials(src, DefaultExpiryMargin)}
+	}
+}
+
+// WithYamlConfigValidation makes CreateCharacter and UpdateCharacter check
+// Character.YamlConfig with ValidateYamlConfig before sending the request, so
+// malformed goals are reported with the line of the problem instead of a
+// vague server error. Updates whose mask doesn't include "yamlConfig" (e.g.
+// AddCustomDialogStyle) don't check it.
+func WithYamlConfigValidation() Option {
+	return func(c *Client) { c.validateYamlConfig = true }
+}
+
+// WithMetadataHeaders changes the names of the headers carrying the key type of
+// studio API requests (Grpc-Metadata-X-Authorization-Bearer-Type by default)
+// and the session id (Grpc-Metadata-Session-Id by default). Inworld serves the
+// REST API with a gRPC gateway, which turns these headers into gRPC metadata.
+// Alternative endpoints, proxies or test servers may expect other names, an
+// empty name suppresses the header.
+func WithMetadataHeaders(bearerTypeHeader, sessionIDHeader string) Option {
+	return func(c *Client) {
+		c.metadataHeaders = metadataHeaders{
+			custom:     true,
+			bearerType: bearerTypeHeader,
+			sessionID:  sessionIDHeader,
+		}
+	}
+}
+
+// WithDialTimeout limits the time of establishing a connection. Like
+// WithResponseHeaderTimeout, it configures a copy of the transport of the http
+// client passed to NewClient (or of http.DefaultTransport if it has none) and
+// has no effect if the transport is not an *http.Transport.
+func WithDialTimeout(d time.Duration) Option {
+	return withTransport(func(t *http.Transport) {
+		t.DialContext = (&net.Dialer{Timeout: d, KeepAlive: 30 * time.Second}).DialContext
+	})
+}
+
+// WithResponseHeaderTimeout limits the time of waiting for the response headers
+// after the request is sent, see WithDialTimeout.
+func WithResponseHeaderTimeout(d time.Duration) Option {
+	return withTransport(func(t *http.Transport) { t.ResponseHeaderTimeout = d })
+}
+
+// WithRequestTimeout limits the time of each request including reading the
+// response body. The context passed to the methods is still respected: the
+// earliest of its deadline, the request timeout and http.Client.Timeout is
+// effective. Methods making several requests (e.g. WaitForDeployment) apply the
+// timeout to each of them, the whole call is bounded only by the context.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *Client) { c.requestTimeout = d }
+}
+
+func withTransport(f func(*http.Transport)) Option {
+	return func(c *Client) {
+		var client http.Client
+		if c.client != nil {
+			client = *c.client
+		}
+
+		rt := client.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+
+		t, ok := rt.(*http.Transport)
+		if !ok {
+			return
+		}
+
+		t = t.Clone()
+		f(t)
+		client.Transport = t
+		c.client = &client
+	}
+}
+
+// WithTransportMiddleware wraps the transport of the http client passed to
+// NewClient (or http.DefaultTransport if it has none) with the middleware, e.g.
+// to sign or log requests. The first middleware is the outermost: it sees the
+// request first and the response last. Middleware of several options is
+// chained in the order of the options. The transport is wrapped after all
+// other options are applied, so WithDialTimeout and similar options configure
+// the wrapped transport.
+func WithTransportMiddleware(middleware ...func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *Client) { c.middleware = append(c.middleware, middleware...) }
+}
+
+// WithRequestSigner sets the function called right before each request is sent
+// (and before each retry), e.g. to add an HMAC signature required by a
+// gateway. By that moment all headers set by the client are in place:
+// Authorization, Accept, Content-Type and gRPC metadata. Only transport
+// middleware runs after the signer. The body is buffered, the signer can read
+// it with http.Request.GetBody without consuming the body being sent. The
+// request fails with the error returned by the signer.
+func WithRequestSigner(sign func(*http.Request) error) Option {
+	return func(c *Client) { c.signer = sign }
+}
+
+// WithDefaultWorkspace sets the workspace used by the methods given an empty
+// workspace id and to qualify bare ids of triggers, see SendTrigger. Methods
+// of the *InDefaultWorkspace family always use it.
+func WithDefaultWorkspace(workspaceID string) Option {
+	return func(c *Client) { c.defaultWorkspace = workspaceID }
+}
+
+// WithStrictPageSize makes the list methods fail on page sizes exceeding
+// MaxPageSize instead of reducing them.
+func WithStrictPageSize() Option {
+	return func(c *Client) { c.strictPageSize = true }
+}
+
+// WithBaseURL sends requests to the given URL instead of https://api.inworld.ai,
+// e.g. to a proxy. The path of the URL, if any, is prepended to the paths of
+// the API. The URL of the request is changed before it is signed, so the
+// signer set with WithRequestSigner and the middleware see the actual URL.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(c *Client) { c.baseURL = baseURL }
+}
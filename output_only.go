@@ -0,0 +1,43 @@
+package inworld
+
+// Output-only fields are managed by the server and can't be set or changed via
+// API, so they are cleared before sending a resource to create or update it.
+// It allows round-tripping resources returned by the API.
+
+// forRequest returns a copy of the character without output-only fields.
+func (c Character) forRequest() Character {
+	c.Name = ""
+	c.CreateTime, c.UpdateTime = nil, nil
+	c.Meta = nil
+	c.SharePortalInfo = nil
+	c.InworldTags = nil
+	c.Scenes = nil
+	return c
+}
+
+// forRequest returns a copy of the scene without output-only fields.
+func (s Scene) forRequest() Scene {
+	s.Name = ""
+	s.CreateTime, s.UpdateTime = nil, nil
+	s.Meta = nil
+	s.InworldTags = nil
+
+	if s.Characters != nil {
+		refs := make([]SceneCharacterReference, len(s.Characters))
+		for i, ref := range s.Characters {
+			refs[i] = SceneCharacterReference{Character: ref.Character}
+		}
+		s.Characters = refs
+	}
+
+	return s
+}
+
+// forRequest returns a copy of the common knowledge without output-only
+// fields.
+func (k CommonKnowledge) forRequest() CommonKnowledge {
+	k.Name = ""
+	k.CreateTime, k.UpdateTime = nil, nil
+	k.InworldTags = nil
+	return k
+}
@@ -0,0 +1,85 @@
+package inworld
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInconsistentPagination is returned by list methods when the PageCursor
+// was received for a request with different parameters.
+var ErrInconsistentPagination = stderrors.New("page cursor belongs to a request with different parameters")
+
+// ErrNoMorePages is returned by list methods given the PageCursor of the last
+// page, see PageCursor.Empty.
+var ErrNoMorePages = stderrors.New("page cursor of the last page has no more pages")
+
+// MaxPageSize is the largest page size of the list requests. Larger sizes are
+// reduced to it, unless the client is created with WithStrictPageSize. Zero
+// page size stands for the default one chosen by the server (50).
+const MaxPageSize = 1000
+
+// pageSize validates the page size of the list request and returns the one to
+// send.
+func (c Client) pageSize(n int32) (int32, error) {
+	switch {
+	case n < 0:
+		return 0, errors.Errorf("page size must not be negative, got %d", n)
+	case n <= MaxPageSize:
+		return n, nil
+	case c.strictPageSize:
+		return 0, errors.Errorf("page size must not exceed %d, got %d", MaxPageSize, n)
+	default:
+		return MaxPageSize, nil
+	}
+}
+
+// PageCursor is a page token bound to the parameters of the list request that
+// returned it. Passing it back instead of the raw token makes the client check
+// that the other parameters of the request are unchanged.
+type PageCursor struct {
+	// Token is the raw page token, the same as NextPageToken of the response.
+	Token string
+
+	fingerprint string
+}
+
+// Empty reports whether there are no more pages.
+func (p PageCursor) Empty() bool { return p.Token == "" }
+
+// token returns the page token to send for a request with the given
+// fingerprint. The raw token is used as is when the cursor is not set. The
+// cursor of the last page is rejected, sending no token would restart the
+// listing from the first page.
+func (p PageCursor) token(fingerprint, raw string) (string, error) {
+	if p == (PageCursor{}) {
+		return raw, nil
+	}
+
+	if p.fingerprint != fingerprint {
+		return "", ErrInconsistentPagination
+	}
+
+	if p.Empty() {
+		return "", ErrNoMorePages
+	}
+
+	return p.Token, nil
+}
+
+// fingerprint returns a digest of the type of the request and its parameters
+// encoded in JSON, which has a stable order of the fields and of the map keys.
+// Paging fields must be reset by the caller.
+func fingerprint(req any) string {
+	// The list requests consist of strings and numbers, so marshaling can't
+	// fail.
+	b, _ := json.Marshal(req)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%T\n%s", req, b)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,120 @@
+package inworld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize int32
+		opts     []Option
+		want     string // "-" stands for no pageSize parameter.
+		wantErr  bool
+	}{
+		{name: "zero", pageSize: 0, want: "-"},
+		{name: "negative", pageSize: -1, wantErr: true},
+		{name: "regular", pageSize: 10, want: "10"},
+		{name: "max", pageSize: MaxPageSize, want: "1000"},
+		{name: "over max", pageSize: MaxPageSize + 1, want: "1000"},
+		{name: "strict max", pageSize: MaxPageSize, opts: []Option{WithStrictPageSize()}, want: "1000"},
+		{name: "strict over max", pageSize: MaxPageSize + 1, opts: []Option{WithStrictPageSize()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := false
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requested = true
+
+				got := "-"
+				if q := r.URL.Query(); q.Has("pageSize") {
+					got = q.Get("pageSize")
+				}
+				if got != tt.want {
+					t.Errorf("got pageSize %q, want %q", got, tt.want)
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}, tt.opts...)
+
+			_, err := c.GetCharacters(context.Background(), GetCharactersRequest{WorkspaceID: "w", PageSize: tt.pageSize})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("no error")
+				}
+				if requested {
+					t.Error("invalid page size is sent")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPageCursor(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch token := r.URL.Query().Get("pageToken"); token {
+		case "":
+			_, _ = w.Write([]byte(`{"characters":[{"name":"workspaces/w/characters/a"}],"nextPageToken":"second"}`))
+		case "second":
+			_, _ = w.Write([]byte(`{"characters":[{"name":"workspaces/w/characters/b"}]}`))
+		default:
+			t.Errorf("unexpected page token %q", token)
+		}
+	})
+
+	req := GetCharactersRequest{WorkspaceID: "w", Filter: `character.name="workspaces/w/characters/a"`}
+	first, err := c.GetCharacters(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed := req
+	changed.Filter = `character.name="workspaces/w/characters/b"`
+	changed.Cursor = first.NextPageCursor
+	if _, err = c.GetCharacters(context.Background(), changed); !errors.Is(err, ErrInconsistentPagination) {
+		t.Errorf("error of the changed filter is %v, want ErrInconsistentPagination", err)
+	}
+
+	req.Cursor = first.NextPageCursor
+	last, err := c.GetCharacters(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.NextPageCursor.Empty() {
+		t.Fatalf("cursor of the last page is %+v", last.NextPageCursor)
+	}
+
+	// The first page is not requested again.
+	req.Cursor = last.NextPageCursor
+	if _, err = c.GetCharacters(context.Background(), req); !errors.Is(err, ErrNoMorePages) {
+		t.Errorf("error of the last cursor is %v, want ErrNoMorePages", err)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	characters := GetCharactersRequest{WorkspaceID: "w", PageSize: 10, Filter: "f"}
+	if fingerprint(characters) != fingerprint(characters) {
+		t.Error("fingerprint is not stable")
+	}
+
+	other := characters
+	other.PageSize = 11
+	if fingerprint(characters) == fingerprint(other) {
+		t.Error("requests with different page sizes have the same fingerprint")
+	}
+
+	// Requests of different lists with the same parameters differ.
+	scenes := GetScenesRequest{WorkspaceID: "w", PageSize: 10, Filter: "f"}
+	knowledge := ListCommonKnowledgeRequest{WorkspaceID: "w", PageSize: 10, Filter: "f"}
+	if fingerprint(scenes) == fingerprint(knowledge) {
+		t.Error("requests of different lists have the same fingerprint")
+	}
+}
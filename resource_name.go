@@ -0,0 +1,75 @@
+package inworld
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ResourceKind is the kind of the resource, the collection segment of its
+// name.
+type ResourceKind string
+
+const (
+	ResourceKindCharacter       ResourceKind = "characters"
+	ResourceKindScene           ResourceKind = "scenes"
+	ResourceKindCommonKnowledge ResourceKind = "common-knowledge"
+	ResourceKindTrigger         ResourceKind = "triggers"
+	ResourceKindSession         ResourceKind = "sessions"
+)
+
+// ParseResourceName splits the resource name of the form
+// workspaces/{workspace}/{kind}/{id} into its parts. The name of a
+// long-running operation of the resource, which has the
+// /operations/{operation} suffix, is parsed as the name of the resource.
+func ParseResourceName(name string) (workspaceID string, kind ResourceKind, id string, err error) {
+	parts := strings.Split(name, "/")
+	if len(parts) == 6 && parts[4] == "operations" && parts[5] != "" {
+		parts = parts[:4]
+	}
+
+	if len(parts) != 4 || parts[0] != "workspaces" || parts[1] == "" || parts[3] == "" {
+		return "", "", "", errors.Errorf("invalid resource name %q", name)
+	}
+
+	switch kind = ResourceKind(parts[2]); kind {
+	case ResourceKindCharacter,
+		ResourceKindScene,
+		ResourceKindCommonKnowledge,
+		ResourceKindTrigger,
+		ResourceKindSession:
+	default:
+		return "", "", "", errors.Errorf("unknown kind of resource %q", name)
+	}
+
+	return parts[1], kind, parts[3], nil
+}
+
+// resourceWorkspace returns the workspace id of the resource name of the
+// given kind.
+func resourceWorkspace(name string, kind ResourceKind) (string, error) {
+	workspaceID, k, _, err := ParseResourceName(name)
+	if err != nil {
+		return "", err
+	}
+
+	if k != kind {
+		return "", errors.Errorf("%q is not a name of %s", name, kind)
+	}
+
+	return workspaceID, nil
+}
+
+// checkOperationName checks the name of a long-running operation of the form
+// workspaces/{workspace}/{kind}/{id}/operations/{operation}.
+func checkOperationName(name string) error {
+	if _, _, _, err := ParseResourceName(name); err != nil {
+		return err
+	}
+
+	if strings.Count(name, "/") != 5 {
+		return errors.Errorf("%q is not a name of an operation", name)
+	}
+
+	return nil
+}
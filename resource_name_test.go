@@ -0,0 +1,77 @@
+package inworld
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestParseResourceName(t *testing.T) {
+	tests := []struct {
+		name        string
+		workspaceID string
+		kind        ResourceKind
+		id          string
+		wantErr     bool
+	}{
+		{name: "workspaces/w/characters/c", workspaceID: "w", kind: ResourceKindCharacter, id: "c"},
+		{name: "workspaces/w/scenes/s", workspaceID: "w", kind: ResourceKindScene, id: "s"},
+		{name: "workspaces/w/common-knowledge/k", workspaceID: "w", kind: ResourceKindCommonKnowledge, id: "k"},
+		{name: "workspaces/w/triggers/t", workspaceID: "w", kind: ResourceKindTrigger, id: "t"},
+		{name: "workspaces/w/sessions/s", workspaceID: "w", kind: ResourceKindSession, id: "s"},
+		{name: "workspaces/w/characters/c/operations/o", workspaceID: "w", kind: ResourceKindCharacter, id: "c"},
+		{name: "workspaces/w/scenes/s/operations/o", workspaceID: "w", kind: ResourceKindScene, id: "s"},
+		{name: "", wantErr: true},
+		{name: "c", wantErr: true},
+		{name: "workspaces/w", wantErr: true},
+		{name: "workspaces/w/characters", wantErr: true},
+		{name: "workspaces/w/characters/", wantErr: true},
+		{name: "workspaces//characters/c", wantErr: true},
+		{name: "/workspaces/w/characters/c", wantErr: true},
+		{name: "workspaces/w/characters/c/", wantErr: true},
+		{name: "projects/w/characters/c", wantErr: true},
+		{name: "workspaces/w/voices/v", wantErr: true},
+		{name: "workspaces/w/Characters/c", wantErr: true},
+		{name: "workspaces/w/characters/c/operations", wantErr: true},
+		{name: "workspaces/w/characters/c/operations/", wantErr: true},
+		{name: "workspaces/w/characters/c/revisions/r", wantErr: true},
+		{name: "workspaces/w/characters/c/operations/o/x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspaceID, kind, id, err := ParseResourceName(tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseResourceName(%q) = %q, %q, %q, want an error", tt.name, workspaceID, kind, id)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseResourceName(%q): %v", tt.name, err)
+			}
+			if workspaceID != tt.workspaceID || kind != tt.kind || id != tt.id {
+				t.Errorf("ParseResourceName(%q) = %q, %q, %q, want %q, %q, %q",
+					tt.name, workspaceID, kind, id, tt.workspaceID, tt.kind, tt.id)
+			}
+		})
+	}
+}
+
+func TestWaitForDeploymentChecksOperationName(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+
+	for _, name := range []string{
+		"",
+		"operations/o",
+		"workspaces/w/characters/c",
+		"workspaces/w/voices/v/operations/o",
+	} {
+		if _, err := c.WaitForDeployment(context.Background(), name); err == nil {
+			t.Errorf("WaitForDeployment(%q) succeeded", name)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package inworld
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// retryPolicy describes how failed requests are retried, see WithRetries. It
+// is built by NewClient from the options, which can be given in any order.
+type retryPolicy struct {
+	maxRetries int
+	// Overrides the default classification of failed requests, see
+	// WithRetryableFunc.
+	retryable func(*http.Response, error) bool
+	// Shared by all copies of the client, nil means no budget.
+	budget *retryBudget
+}
+
+// newRetryPolicy returns the policy configured by the retry options, nil if
+// retries are disabled.
+func newRetryPolicy(c Client) *retryPolicy {
+	if c.maxRetries <= 0 {
+		return nil
+	}
+	return &retryPolicy{maxRetries: c.maxRetries, retryable: c.retryable, budget: c.retryBudget}
+}
+
+// WithRetries makes the client retry failed requests up to maxRetries times
+// with exponential backoff. Requests rejected with ErrResourceExhausted are
+// retried regardless of the method, requests failed with ErrUnavailable, a
+// gateway timeout or a network error are retried only if the method is
+// idempotent (GET, HEAD, PUT, DELETE). The body is buffered to be sent again.
+// Retrying stops when the context is canceled. A non-positive value disables
+// retries, which is the default.
+func WithRetries(maxRetries int) Option {
+	return func(c *Client) { c.maxRetries = maxRetries }
+}
+
+// WithRetryBudget limits retries made by all copies of the client to the given
+// fraction of the requests, e.g. 0.1 allows at most 10% of extra load on top of
+// the original requests, so retries don't amplify the load during outages. The
+// budget is a token bucket: each request adds ratio tokens, each retry takes
+// one, up to 10 retries can be saved up. When the budget is exhausted, failed
+// requests return the error without retrying. It has effect only together with
+// WithRetries.
+func WithRetryBudget(ratio float64) Option {
+	return func(c *Client) { c.retryBudget = newRetryBudget(ratio) }
+}
+
+// WithRetryableFunc replaces the default decision of WithRetries on which
+// failed requests are retried. The function is called after each failed
+// attempt with the http response (its body is already closed, nil if none was
+// received) and the error, which is an *Error for error responses. Some
+// proxies and gateways report errors in the body of a 200 OK response, such
+// bodies (with a non-zero "code" and a "message") are passed to the function
+// as an *Error too, so it can decide to retry them. Unless they are retried,
+// the response is decoded and returned without an error, as it is without the
+// function. Detecting them buffers successful bodies. Requests are still not
+// retried after the context is canceled or the retries or the budget are
+// exhausted.
+//
+// The function can't make non-idempotent requests (POST, PATCH) retried
+// unless they are rejected with ErrResourceExhausted: the server may have
+// applied them, so retrying may e.g. create a character twice. It can only
+// prevent retries of such requests.
+//
+// It has effect only together with WithRetries.
+func WithRetryableFunc(retryable func(resp *http.Response, err error) bool) Option {
+	return func(c *Client) { c.retryable = retryable }
+}
+
+// retryBudgetMaxTokens is the max number of retries saved up by retryBudget.
+const retryBudgetMaxTokens = 10
+
+// retryBudget is a token bucket limiting the number of retries.
+type retryBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	tokens float64
+}
+
+func newRetryBudget(ratio float64) *retryBudget {
+	return &retryBudget{ratio: ratio, tokens: retryBudgetMaxTokens}
+}
+
+// deposit is called for each original request.
+func (b *retryBudget) deposit() {
+	b.mu.Lock()
+	b.tokens = min(b.tokens+b.ratio, retryBudgetMaxTokens)
+	b.mu.Unlock()
+}
+
+// withdraw reports whether a retry is allowed.
+func (b *retryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// retryRequest sends the request with do retrying it according to the policy
+// of the client.
+func retryRequest[T any](
+	c Client,
+	r *http.Request,
+	do func(Client, *http.Request) (T, *http.Response, error),
+) (T, error) {
+	if err := bufferBody(r); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	p := c.retry
+	if p.budget != nil {
+		p.budget.deposit()
+	}
+
+	for attempt := 0; ; attempt++ {
+		req := r.Clone(r.Context())
+		if r.GetBody != nil {
+			// GetBody of a buffered body never fails.
+			req.Body, _ = r.GetBody()
+		}
+
+		resp, httpResp, err := do(c, req)
+
+		// An error reported with 200 OK is only shown to the classifier, the
+		// response is returned as decoded unless it is retried.
+		failure := err
+		var okErr *statusOKError
+		if stderrors.As(err, &okErr) {
+			err, failure = nil, okErr.err
+		}
+
+		if failure == nil || attempt >= p.maxRetries || r.Context().Err() != nil {
+			return resp, err
+		}
+
+		retry := retryable(r, failure)
+		if p.retryable != nil {
+			retry = p.retryable(httpResp, failure) &&
+				(idempotent(r.Method) || stderrors.Is(failure, ErrResourceExhausted))
+		}
+		if !retry {
+			return resp, err
+		}
+
+		if p.budget != nil && !p.budget.withdraw() {
+			return resp, err
+		}
+
+		if serr := c.getClock().Sleep(r.Context(), backoff(attempt)); serr != nil {
+			return resp, combine(err, errors.WithStack(serr))
+		}
+	}
+}
+
+// bufferBody reads the body of the request to be able to send it again.
+func bufferBody(r *http.Request) error {
+	if r.Body == nil || r.Body == http.NoBody || r.GetBody != nil {
+		return nil
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(err, "reading request body")
+	}
+
+	r.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(b)), nil }
+	r.Body, _ = r.GetBody()
+	r.ContentLength = int64(len(b))
+
+	return nil
+}
+
+// retryable reports whether the request failed with err can be retried.
+func retryable(r *http.Request, err error) bool {
+	if r.Context().Err() != nil {
+		return false
+	}
+
+	var e *Error
+	if stderrors.As(err, &e) {
+		switch e.Code {
+		case codes.ResourceExhausted:
+			return true
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return idempotent(r.Method)
+		default:
+			return false
+		}
+	}
+
+	// Network errors are returned by http.Client wrapped into *url.Error.
+	var ue *url.Error
+	return idempotent(r.Method) && stderrors.As(err, &ue)
+}
+
+// idempotent reports whether requests with the method can be sent again
+// without changing the result.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the retry with full jitter: a random
+// duration up to 200ms doubled for each attempt, but not longer than 5s.
+func backoff(attempt int) time.Duration {
+	d := 5 * time.Second
+	if attempt < 5 {
+		d = min(200*time.Millisecond<<attempt, d)
+	}
+	return time.Duration(rand.Int63n(int64(d)) + 1)
+}
@@ -0,0 +1,230 @@
+package inworld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRetryBudgetCapsSustainedFailures(t *testing.T) {
+	var attempts atomic.Int64
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetries(5), WithRetryBudget(0.1), WithClock(noSleepClock{}))
+
+	const requests = 50
+	for i := 0; i < requests; i++ {
+		if _, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", ""); err == nil {
+			t.Fatal("request succeeded")
+		}
+	}
+
+	// The budget starts with retryBudgetMaxTokens and gets 0.1 per request.
+	retries := attempts.Load() - requests
+	if maxRetries := int64(retryBudgetMaxTokens + requests/10); retries > maxRetries {
+		t.Errorf("%d retries, want at most %d", retries, maxRetries)
+	}
+	if retries == 0 {
+		t.Error("no retries")
+	}
+}
+
+func TestRetriesWithoutBudget(t *testing.T) {
+	var attempts atomic.Int64
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetries(3), WithClock(noSleepClock{}))
+
+	if _, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", ""); err == nil {
+		t.Fatal("request succeeded")
+	}
+
+	if n := attempts.Load(); n != 4 {
+		t.Errorf("%d attempts, want 4", n)
+	}
+}
+
+func TestRetryableFuncKeepsIdempotencyGate(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		post     bool
+		attempts int64
+	}{
+		{name: "get unavailable", status: http.StatusServiceUnavailable, attempts: 3},
+		{name: "post unavailable", status: http.StatusServiceUnavailable, post: true, attempts: 1},
+		{name: "post internal", status: http.StatusInternalServerError, post: true, attempts: 1},
+		{name: "post resource exhausted", status: http.StatusTooManyRequests, post: true, attempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int64
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			},
+				WithRetries(2),
+				WithRetryableFunc(func(*http.Response, error) bool { return true }),
+				WithClock(noSleepClock{}),
+			)
+
+			var err error
+			if tt.post {
+				_, err = c.CreateCharacter(context.Background(), "w", Character{})
+			} else {
+				_, err = c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+			}
+			if err == nil {
+				t.Fatal("request succeeded")
+			}
+
+			if n := attempts.Load(); n != tt.attempts {
+				t.Errorf("%d attempts, want %d", n, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryableFuncCanPreventRetries(t *testing.T) {
+	var attempts atomic.Int64
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	},
+		WithRetries(2),
+		WithRetryableFunc(func(_ *http.Response, err error) bool { return !errors.Is(err, ErrResourceExhausted) }),
+		WithClock(noSleepClock{}),
+	)
+
+	if _, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", ""); !errors.Is(err, ErrResourceExhausted) {
+		t.Fatalf("error is %v, want ErrResourceExhausted", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
+
+func TestErrorWithStatusOK(t *testing.T) {
+	var attempts atomic.Int64
+	var classified []codes.Code
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"code":14,"message":"backend is unavailable","details":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"workspaces/w/characters/c"}`))
+	},
+		WithRetries(2),
+		WithRetryableFunc(func(resp *http.Response, err error) bool {
+			if resp == nil || resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected response %v", resp)
+			}
+			var e *Error
+			if errors.As(err, &e) {
+				classified = append(classified, e.Code)
+			}
+			return errors.Is(err, ErrUnavailable)
+		}),
+		WithClock(noSleepClock{}),
+	)
+
+	ch, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Name != "workspaces/w/characters/c" {
+		t.Errorf("unexpected character %+v", ch)
+	}
+	if len(classified) != 1 || classified[0] != codes.Unavailable {
+		t.Errorf("classified %v, want [Unavailable]", classified)
+	}
+}
+
+func TestErrorWithStatusOKNotRetried(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "without classifier", opts: []Option{WithRetries(2)}},
+		{name: "declined by classifier", opts: []Option{
+			WithRetries(2),
+			WithRetryableFunc(func(*http.Response, error) bool { return false }),
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int64
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				_, _ = w.Write([]byte(`{"code":"NOT_FOUND","message":"no such character"}`))
+			}, append(tt.opts, WithClock(noSleepClock{}))...)
+
+			if _, err := c.GetCharacter(context.Background(), "workspaces/w/characters/c", ""); err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if n := attempts.Load(); n != 1 {
+				t.Errorf("%d attempts, want 1", n)
+			}
+		})
+	}
+}
+
+func TestRetryOptionsOrder(t *testing.T) {
+	classify := WithRetryableFunc(func(*http.Response, error) bool { return true })
+
+	for _, tt := range []struct {
+		name           string
+		opts           []Option
+		wantRetries    int
+		wantBudget     bool
+		wantClassifier bool
+	}{
+		{name: "budget alone", opts: []Option{WithRetryBudget(0.1)}},
+		{name: "classifier alone", opts: []Option{classify}},
+		{
+			name:           "retries last",
+			opts:           []Option{WithRetryBudget(0.1), classify, WithRetries(3)},
+			wantRetries:    3,
+			wantBudget:     true,
+			wantClassifier: true,
+		},
+		{
+			name:           "retries first",
+			opts:           []Option{WithRetries(3), classify, WithRetryBudget(0.1)},
+			wantRetries:    3,
+			wantBudget:     true,
+			wantClassifier: true,
+		},
+		{name: "disabled", opts: []Option{WithRetries(3), WithRetryBudget(0.1), classify, WithRetries(0)}},
+		{
+			name:           "reenabled",
+			opts:           []Option{WithRetryBudget(0.1), classify, WithRetries(0), WithRetries(2)},
+			wantRetries:    2,
+			wantBudget:     true,
+			wantClassifier: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewClient("", "studio-key", http.Client{}, tt.opts...).retry
+			if tt.wantRetries == 0 {
+				if p != nil {
+					t.Errorf("retries are enabled: %+v", p)
+				}
+				return
+			}
+
+			if p == nil {
+				t.Fatal("retries are disabled")
+			}
+			if p.maxRetries != tt.wantRetries || (p.budget != nil) != tt.wantBudget || (p.retryable != nil) != tt.wantClassifier {
+				t.Errorf("unexpected policy %+v", p)
+			}
+		})
+	}
+}
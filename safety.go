@@ -0,0 +1,125 @@
+package inworld
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SafetyTopic is a key of SafetyConfigEntry.
+type SafetyTopic string
+
+const (
+	SafetyTopicAlcohol      SafetyTopic = "TOPIC_ALCOHOL"
+	SafetyTopicPolitics     SafetyTopic = "TOPIC_POLITICS"
+	SafetyTopicViolence     SafetyTopic = "TOPIC_VIOLENCE"
+	SafetyTopicReligion     SafetyTopic = "TOPIC_RELIGION"
+	SafetyTopicProfanity    SafetyTopic = "TOPIC_PROFANITY"
+	SafetyTopicAdultTopics  SafetyTopic = "TOPIC_ADULT_TOPICS"
+	SafetyTopicSubstanceUse SafetyTopic = "TOPIC_SUBSTANCE_USE"
+)
+
+// safetyTopics are the declared topics in the order of declaration.
+var safetyTopics = []SafetyTopic{
+	SafetyTopicAlcohol,
+	SafetyTopicPolitics,
+	SafetyTopicViolence,
+	SafetyTopicReligion,
+	SafetyTopicProfanity,
+	SafetyTopicAdultTopics,
+	SafetyTopicSubstanceUse,
+}
+
+// MissingTopics returns the declared topics the entry doesn't configure, in
+// the order of declaration. Topics set to SafetyLevelUnspecified are missing
+// too. The server applies its defaults to the missing topics.
+func (e SafetyConfigEntry) MissingTopics() []SafetyTopic {
+	var res []SafetyTopic
+	for _, t := range safetyTopics {
+		if l, ok := e[string(t)]; !ok || l == "" || l == SafetyLevelUnspecified {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// safetyLevelStrictness orders the known levels from the least strict.
+var safetyLevelStrictness = map[SafetyLevel]int{
+	SafetyLevelNoControl:     1,
+	SafetyLevelMildControl:   2,
+	SafetyLevelStrictControl: 3,
+}
+
+// StrictestLevel returns the strictest level configured for any topic,
+// SafetyLevelUnspecified if there are none. Unknown levels are ignored.
+func (e SafetyConfigEntry) StrictestLevel() SafetyLevel {
+	res := SafetyLevelUnspecified
+	for _, l := range e {
+		if safetyLevelStrictness[l] > safetyLevelStrictness[res] {
+			res = l
+		}
+	}
+	return res
+}
+
+// safetyKeywords are the words ClassifyText looks for, in lower case. Short
+// words that are common in other senses (e.g. "gin", "god" or "vote") are left
+// out to avoid false positives.
+var safetyKeywords = map[SafetyTopic][]string{
+	SafetyTopicAlcohol: {
+		"alcohol", "beer", "wine", "vodka", "whiskey", "whisky", "tequila",
+		"drunk", "hangover", "booze", "liquor", "cocktail",
+	},
+	SafetyTopicPolitics: {
+		"politics", "political", "election", "president", "parliament",
+		"congress", "senator", "democrat", "republican", "voting",
+		"government", "politician",
+	},
+	SafetyTopicViolence: {
+		"kill", "murder", "stab", "shoot", "gun", "weapon", "blood", "fight",
+		"attack", "bomb", "torture", "assault", "violence", "violent",
+	},
+	SafetyTopicReligion: {
+		"religion", "religious", "church", "mosque", "temple", "bible",
+		"quran", "pray", "prayer", "jesus", "allah", "buddha", "atheist",
+	},
+	SafetyTopicProfanity: {
+		"fuck", "fucking", "shit", "bitch", "bastard", "asshole", "crap",
+		"dick",
+	},
+	SafetyTopicAdultTopics: {
+		"sex", "sexual", "porn", "nude", "naked", "erotic", "fetish",
+	},
+	SafetyTopicSubstanceUse: {
+		"drug", "drugs", "cocaine", "heroin", "weed", "marijuana", "meth",
+		"overdose", "smoke", "smoking", "cigarette", "lsd",
+	},
+}
+
+// ClassifyText returns the safety topics the text touches, sorted. The API has
+// no moderation endpoint, so this is a best-effort local pre-filter matching
+// English keywords as whole words, e.g. "drug" doesn't match "drugstore". It
+// neither reproduces the classification done by Inworld nor depends on
+// SafetyConfigEntry of the character. It may be used to warn about the text
+// before sending it, but not to rely on the response being filtered or not.
+func ClassifyText(text string) []SafetyTopic {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[w] = true
+	}
+
+	var res []SafetyTopic
+	for topic, keywords := range safetyKeywords {
+		for _, k := range keywords {
+			if words[k] {
+				res = append(res, topic)
+				break
+			}
+		}
+	}
+
+	slices.Sort(res)
+	return res
+}
@@ -0,0 +1,33 @@
+package inworld
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClassifyText(t *testing.T) {
+	tests := []struct {
+		text string
+		want []SafetyTopic
+	}{
+		{text: "Let's meet at the drugstore.", want: nil},
+		{text: "He sold drugs.", want: []SafetyTopic{SafetyTopicSubstanceUse}},
+		{text: "DRUG-free zone", want: []SafetyTopic{SafetyTopicSubstanceUse}},
+		{text: "The engine needs an overhaul, it's a cotton gin.", want: nil},
+		{text: "Oh my god, the vote is tomorrow, damn!", want: nil},
+		{text: "A rum cake for the gods.", want: nil},
+		{text: "Skill issues and a killjoy.", want: nil},
+		{text: "They want to kill him.", want: []SafetyTopic{SafetyTopicViolence}},
+		{text: "gun2 is not a word", want: nil},
+		{
+			text: "A drunk senator went to church.",
+			want: []SafetyTopic{SafetyTopicAlcohol, SafetyTopicPolitics, SafetyTopicReligion},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyText(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("ClassifyText(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
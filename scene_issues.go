@@ -0,0 +1,104 @@
+package inworld
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SceneIssue describes a dangling or malformed reference of the scene found by
+// ValidateScene.
+type SceneIssue struct {
+	Kind SceneIssueKind
+	// Resource name the scene refers to.
+	Reference string
+}
+
+// SceneIssueKind is the kind of the SceneIssue.
+type SceneIssueKind string
+
+const (
+	// SceneIssueMissingCharacter means the referenced character doesn't exist.
+	SceneIssueMissingCharacter SceneIssueKind = "MISSING_CHARACTER"
+	// SceneIssueMissingCommonKnowledge means the referenced common knowledge
+	// doesn't exist.
+	SceneIssueMissingCommonKnowledge SceneIssueKind = "MISSING_COMMON_KNOWLEDGE"
+	// SceneIssueEmptyTrigger means a scene trigger has no trigger reference.
+	SceneIssueEmptyTrigger SceneIssueKind = "EMPTY_TRIGGER"
+	// SceneIssueMalformedTrigger means the trigger reference is not a name of
+	// the form workspaces/{workspace}/triggers/{trigger}.
+	SceneIssueMalformedTrigger SceneIssueKind = "MALFORMED_TRIGGER"
+	// SceneIssueForeignTrigger means the referenced trigger belongs to another
+	// workspace than the scene.
+	SceneIssueForeignTrigger SceneIssueKind = "FOREIGN_TRIGGER"
+)
+
+// ValidateScene checks that the characters and the common knowledge the scene
+// refers to exist and that its triggers are well-formed references to the
+// workspace of the scene. It returns the issues found, nil if there are none.
+// Existence of triggers can't be checked, since the API provides no way to
+// list or get triggers. Format of the scene id:
+// workspaces/{workspace}/scenes/{scene}
+func (c Client) ValidateScene(ctx context.Context, sceneID string) ([]SceneIssue, error) {
+	workspaceID, err := resourceWorkspace(sceneID, ResourceKindScene)
+	if err != nil {
+		return nil, err
+	}
+
+	scene, err := c.GetScene(ctx, sceneID, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "getting scene")
+	}
+
+	issues := triggerIssues(workspaceID, scene.SceneTriggers)
+
+	names := make([]string, len(scene.Characters))
+	for i, ref := range scene.Characters {
+		names[i] = ref.Character
+	}
+
+	if len(names) > 0 {
+		_, missing, err := c.GetCharactersByNames(ctx, workspaceID, names)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting characters")
+		}
+
+		for _, name := range missing {
+			issues = append(issues, SceneIssue{Kind: SceneIssueMissingCharacter, Reference: name})
+		}
+	}
+
+	for _, name := range scene.CommonKnowledge {
+		_, ok, err := c.GetCommonKnowledgeOK(ctx, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting common knowledge %s", name)
+		}
+
+		if !ok {
+			issues = append(issues, SceneIssue{Kind: SceneIssueMissingCommonKnowledge, Reference: name})
+		}
+	}
+
+	return issues, nil
+}
+
+// triggerIssues checks the trigger references of the scene in the workspace.
+func triggerIssues(workspaceID string, triggers []SceneTrigger) []SceneIssue {
+	var issues []SceneIssue
+	for _, t := range triggers {
+		if t.Trigger == "" {
+			issues = append(issues, SceneIssue{Kind: SceneIssueEmptyTrigger})
+			continue
+		}
+
+		ws, kind, _, err := ParseResourceName(t.Trigger)
+		switch {
+		case err != nil, kind != ResourceKindTrigger, strings.Count(t.Trigger, "/") != 3:
+			issues = append(issues, SceneIssue{Kind: SceneIssueMalformedTrigger, Reference: t.Trigger})
+		case ws != workspaceID:
+			issues = append(issues, SceneIssue{Kind: SceneIssueForeignTrigger, Reference: t.Trigger})
+		}
+	}
+	return issues
+}
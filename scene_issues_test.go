@@ -0,0 +1,46 @@
+package inworld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestValidateSceneTriggers(t *testing.T) {
+	scene := Scene{
+		Name: "workspaces/w/scenes/s",
+		SceneTriggers: []SceneTrigger{
+			{Trigger: "workspaces/w/triggers/ok"},
+			{Description: "no reference"},
+			{Trigger: "greet"},
+			{Trigger: "workspaces/w/characters/c"},
+			{Trigger: "workspaces/w/triggers/t/operations/o"},
+			{Trigger: "workspaces/other/triggers/t"},
+		},
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/studio/v1/"+scene.Name {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(scene)
+	})
+
+	issues, err := c.ValidateScene(context.Background(), scene.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SceneIssue{
+		{Kind: SceneIssueEmptyTrigger},
+		{Kind: SceneIssueMalformedTrigger, Reference: "greet"},
+		{Kind: SceneIssueMalformedTrigger, Reference: "workspaces/w/characters/c"},
+		{Kind: SceneIssueMalformedTrigger, Reference: "workspaces/w/triggers/t/operations/o"},
+		{Kind: SceneIssueForeignTrigger, Reference: "workspaces/other/triggers/t"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("issues are %+v, want %+v", issues, want)
+	}
+}
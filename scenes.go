@@ -2,8 +2,14 @@ package inworld
 
 import (
 	"context"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -15,21 +21,22 @@ import (
 // deployment for activation.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/scenes/#create-scene
 func (c Client) CreateScene(ctx context.Context, workspaceID string, scene Scene) (Scene, error) {
-	if workspaceID == "" {
-		return Scene{}, errors.New("workspace id is required")
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return Scene{}, err
 	}
 
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
 		apiStudioV1.JoinPath("workspaces", workspaceID, "scenes").String(),
-		newReader(scene),
+		newReader(scene.forRequest()),
 	)
 	if err != nil {
 		return Scene{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[Scene](c, r)
+	return withValidationError(sendStudioAPIRequest[Scene](c, r))
 }
 
 // GetScene to get a specific scene within a workspace.
@@ -66,13 +73,14 @@ func (c Client) GetScene(
 
 // DeployScene asynchronously deploys the scene. The deployment process is
 // managed as a long-running operation (LRO). The progress and result of this
-// operation should be monitored using the returned LRO object. Upon successful
-// completion, all characters will reflect the updates during their
-// interactions. In the event of a failure, the operation should be retried.
+// operation should be monitored using the returned LRO object, see
+// PollOperation. Upon successful completion, all characters will reflect the
+// updates during their interactions. In the event of a failure, the operation
+// should be retried.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/scenes/#deploy-scene
-func (c Client) DeployScene(ctx context.Context, sceneID string) (DeploymentResponse, error) {
+func (c Client) DeployScene(ctx context.Context, sceneID string) (Operation, error) {
 	if sceneID == "" {
-		return DeploymentResponse{}, errors.New("scene id is required")
+		return Operation{}, errors.New("scene id is required")
 	}
 
 	r, err := http.NewRequestWithContext(
@@ -82,10 +90,10 @@ func (c Client) DeployScene(ctx context.Context, sceneID string) (DeploymentResp
 		http.NoBody,
 	)
 	if err != nil {
-		return DeploymentResponse{}, errors.WithStack(err)
+		return Operation{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[DeploymentResponse](c, r)
+	return startOperation(c, r)
 }
 
 // GetScenes returns a list of scenes that can be filtered by several criteria.
@@ -96,8 +104,20 @@ func (c Client) GetScenes(
 	ctx context.Context,
 	req GetScenesRequest,
 ) (GetScenesResponse, error) {
-	if req.WorkspaceID == "" {
-		return GetScenesResponse{}, errors.New("workspace id is required")
+	workspaceID, err := c.workspace(req.WorkspaceID)
+	if err != nil {
+		return GetScenesResponse{}, err
+	}
+	req.WorkspaceID = workspaceID
+
+	if req.PageSize, err = c.pageSize(req.PageSize); err != nil {
+		return GetScenesResponse{}, err
+	}
+
+	fp := req.fingerprint()
+	pageToken, err := req.Cursor.token(fp, req.PageToken)
+	if err != nil {
+		return GetScenesResponse{}, errors.WithStack(err)
 	}
 
 	url := apiStudioV1.JoinPath("workspaces", req.WorkspaceID, "scenes")
@@ -109,8 +129,8 @@ func (c Client) GetScenes(
 	if req.PageSize > 0 {
 		q.Add("pageSize", strconv.FormatInt(int64(req.PageSize), 10))
 	}
-	if req.PageToken != "" {
-		q.Add("pageToken", req.PageToken)
+	if pageToken != "" {
+		q.Add("pageToken", pageToken)
 	}
 
 	url.RawQuery = q.Encode()
@@ -125,32 +145,161 @@ func (c Client) GetScenes(
 		return GetScenesResponse{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[GetScenesResponse](c, r)
+	resp, err := sendStudioAPIRequest[GetScenesResponse](c, r)
+	resp.NextPageCursor = PageCursor{Token: resp.NextPageToken, fingerprint: fp}
+	return resp, err
 }
 
 // UpdateScene updates the specified character. Changes to the character are not
-// reflected in conversation until the character is deployed.
+// reflected in conversation until the character is deployed. Only the fields
+// present in the request are updated, and empty fields of the scene are not
+// sent, so updating e.g. only the description keeps the characters, triggers,
+// common knowledge and time period of the scene. As a consequence, these
+// fields can't be cleared with UpdateScene.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/scenes/#update-scene
 func (c Client) UpdateScene(
 	ctx context.Context,
 	sceneID string,
 	k Scene,
 ) (Scene, error) {
+	return c.patchScene(ctx, sceneID, k)
+}
+
+// patchScene updates the scene. When updateMask is not empty, only the listed
+// fields (JSON paths, e.g. "commonKnowledge") are changed, and empty lists
+// among them are cleared.
+func (c Client) patchScene(ctx context.Context, sceneID string, s Scene, updateMask ...string) (Scene, error) {
 	if sceneID == "" {
 		return Scene{}, errors.New("scene id is required")
 	}
 
+	for _, path := range updateMask {
+		if path == "" {
+			return Scene{}, errors.New("update mask path cannot be empty")
+		}
+	}
+
+	url := apiStudioV1.JoinPath(sceneID)
+	if len(updateMask) > 0 {
+		q := url.Query()
+		q.Add("updateMask", strings.Join(updateMask, ","))
+		url.RawQuery = q.Encode()
+	}
+
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPatch,
-		apiStudioV1.JoinPath(sceneID).String(),
-		newReader(k),
+		url.String(),
+		newReader(s.forRequest()),
 	)
 	if err != nil {
 		return Scene{}, errors.WithStack(err)
 	}
 
-	return sendStudioAPIRequest[Scene](c, r)
+	return withValidationError(sendStudioAPIRequest[Scene](c, r))
+}
+
+// LinkCommonKnowledgeToScene adds the common knowledge to the scene unless it
+// is already there. The common knowledge is given either by the resource name
+// or by the display name, which must be unique in the workspace of the scene.
+// Other fields of the scene are not changed.
+func (c Client) LinkCommonKnowledgeToScene(ctx context.Context, sceneID, commonKnowledge string) (Scene, error) {
+	scene, name, err := c.sceneCommonKnowledge(ctx, sceneID, commonKnowledge)
+	if err != nil || slices.Contains(scene.CommonKnowledge, name) {
+		return scene, err
+	}
+
+	scene.CommonKnowledge = append(scene.CommonKnowledge, name)
+	return c.patchScene(ctx, sceneID, Scene{CommonKnowledge: scene.CommonKnowledge}, "commonKnowledge")
+}
+
+// UnlinkCommonKnowledgeFromScene removes the common knowledge, given like for
+// LinkCommonKnowledgeToScene, from the scene if it is there. Other fields of
+// the scene are not changed.
+func (c Client) UnlinkCommonKnowledgeFromScene(ctx context.Context, sceneID, commonKnowledge string) (Scene, error) {
+	scene, name, err := c.sceneCommonKnowledge(ctx, sceneID, commonKnowledge)
+	if err != nil || !slices.Contains(scene.CommonKnowledge, name) {
+		return scene, err
+	}
+
+	scene.CommonKnowledge = slices.DeleteFunc(scene.CommonKnowledge, func(s string) bool { return s == name })
+	return c.patchScene(ctx, sceneID, Scene{CommonKnowledge: scene.CommonKnowledge}, "commonKnowledge")
+}
+
+// CloneScene creates a copy of the scene in the target workspace (the default
+// one if empty, see WithDefaultWorkspace) with the new display name, or the
+// display name of the source if it's empty. Fields managed by the server are
+// not copied. References to characters, common knowledge and triggers are kept
+// as is, so cloning to another workspace requires these resources to be
+// remapped with UpdateScene, see ValidateScene. Like CreateScene, the clone
+// must be deployed afterwards.
+func (c Client) CloneScene(ctx context.Context, sourceSceneID, targetWorkspaceID, newDisplayName string) (Scene, error) {
+	source, err := c.GetScene(ctx, sourceSceneID, "")
+	if err != nil {
+		return Scene{}, errors.Wrap(err, "getting source scene")
+	}
+
+	if newDisplayName != "" {
+		source.DisplayName = newDisplayName
+	}
+
+	return c.CreateScene(ctx, targetWorkspaceID, source)
+}
+
+// sceneCommonKnowledge returns the scene and the resource name of the common
+// knowledge given by the resource or display name.
+func (c Client) sceneCommonKnowledge(
+	ctx context.Context,
+	sceneID, commonKnowledge string,
+) (scene Scene, name string, err error) {
+	workspaceID, err := resourceWorkspace(sceneID, ResourceKindScene)
+	if err != nil {
+		return Scene{}, "", err
+	}
+
+	if commonKnowledge == "" {
+		return Scene{}, "", errors.New("common knowledge is required")
+	}
+
+	name = commonKnowledge
+	if _, kind, _, err := ParseResourceName(commonKnowledge); err != nil || kind != ResourceKindCommonKnowledge {
+		if name, err = c.commonKnowledgeByDisplayName(ctx, workspaceID, commonKnowledge); err != nil {
+			return Scene{}, "", err
+		}
+	}
+
+	scene, err = c.GetScene(ctx, sceneID, "")
+	if err != nil {
+		return Scene{}, "", errors.Wrap(err, "getting scene")
+	}
+
+	return scene, name, nil
+}
+
+// commonKnowledgeByDisplayName returns the resource name of the only common
+// knowledge of the workspace with the display name. The common knowledge
+// can't be filtered by the display name on the server, so all of it is
+// listed.
+func (c Client) commonKnowledgeByDisplayName(ctx context.Context, workspaceID, displayName string) (string, error) {
+	var names []string
+	err := c.RangeCommonKnowledge(ctx, ListCommonKnowledgeRequest{WorkspaceID: workspaceID}, func(k CommonKnowledge) error {
+		if k.DisplayName == displayName {
+			names = append(names, k.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "listing common knowledge")
+	}
+
+	switch len(names) {
+	case 0:
+		return "", errors.Errorf("common knowledge %q not found in workspace %s", displayName, workspaceID)
+	case 1:
+		return names[0], nil
+	default:
+		return "", errors.Errorf("%d common knowledge entries are named %q in workspace %s", len(names), displayName, workspaceID)
+	}
 }
 
 // DeleteScene to delete a specific scene within a workspace.
@@ -177,9 +326,9 @@ func (c Client) DeleteScene(ctx context.Context, sceneID string) error {
 // GetScenesRequest is a struct representing a request to list scene items.
 // https://docs.inworld.ai/docs/tutorial-basics/studio-api/reference/scenes/#request-body-2
 type GetScenesRequest struct {
-	WorkspaceID string // Required.
+	WorkspaceID string // Required, unless set with WithDefaultWorkspace.
 	// Max number of items to retrieve per page. Default is 50.
-	PageSize int32 // Optional.
+	PageSize int32 // Optional, see MaxPageSize.
 	// A page token, received from a previous GetScenes call. Provide this
 	// to retrieve the subsequent page. When paginating, all other parameters
 	// provided to GetScenesRequest must stay the same.
@@ -193,6 +342,16 @@ type GetScenesRequest struct {
 	//  scene.name=workspaces/{workspace_id}/scenes/{uuid1} OR
 	//  scene.name=workspaces/{workspace_id}/scenes/{uuid2}.
 	Filter string // Optional.
+	// A cursor received from a previous GetScenes call. Takes precedence over
+	// PageToken, unlike the raw token GetScenes returns
+	// ErrInconsistentPagination if other parameters have changed, and
+	// ErrNoMorePages for the cursor of the last page.
+	Cursor PageCursor // Optional.
+}
+
+func (req GetScenesRequest) fingerprint() string {
+	req.PageToken, req.Cursor = "", PageCursor{}
+	return fingerprint(req)
 }
 
 // GetScenesResponse is a struct representing the response from a get
@@ -201,6 +360,8 @@ type GetScenesRequest struct {
 type GetScenesResponse struct {
 	Scenes        []Scene `json:"scenes"`
 	NextPageToken string  `json:"nextPageToken"`
+	// NextPageCursor is NextPageToken bound to the parameters of the request.
+	NextPageCursor PageCursor `json:"-"`
 }
 
 // Scene represents a description of the Scene.
@@ -208,30 +369,106 @@ type Scene struct {
 	// Immutable. This field can't be set or changed via API. Automatically
 	// generated resource name by the system based on display_name property.
 	Name string `json:"name,omitempty"` // Optional.
+	// Time of the creation and of the last update, nil if not returned by the
+	// server. These fields can't be set or changed via API.
+	// There is no documentation for these fields.
+	CreateTime *time.Time `json:"createTime,omitempty"` // Optional.
+	UpdateTime *time.Time `json:"updateTime,omitempty"` // Optional.
 	// Scene's description. This field should give clear information about the
 	// scene.
 	Description string `json:"description,omitempty"` // Optional.
 	// List of assigned triggers and scenes.
-	SceneTriggers []SceneTrigger `json:"sceneTriggers"` // Optional.
+	SceneTriggers []SceneTrigger `json:"sceneTriggers,omitempty"` // Optional.
 	// User specified name.
 	DisplayName string `json:"displayName,omitempty"` // Optional.
 	// Immutable. This field can't be set or changed via API. Meta character
 	// information.
-	Meta *Meta `json:"meta"` // Optional.
+	Meta *Meta `json:"meta,omitempty"` // Optional.
 	// List of assigned common_knowledge (contains resource references).
-	CommonKnowledge []string `json:"commonKnowledge"` // Optional.
+	CommonKnowledge []string `json:"commonKnowledge,omitempty"` // Optional.
 	// Current time period.
-	TimePeriod string `json:"timePeriod"` // Optional.
+	TimePeriod string `json:"timePeriod,omitempty"` // Optional.
 	// List of references to scene characters.
-	Characters []SceneCharacterReference `json:"characters"` // Optional.
+	Characters []SceneCharacterReference `json:"characters,omitempty"` // Optional.
 
+	// Tags assigned by Inworld. This field is output only.
 	// There is no documentation for this field.
-	InworldTags []any `json:"inworldTags"`
+	InworldTags []Tag `json:"inworldTags,omitempty"`
+	// Assets of the scene, nil if there are none.
 	// There is no documentation for this field.
-	DefaultSceneAssets struct {
-		SceneIMG         string `json:"sceneImg"`
-		SceneIMGOriginal string `json:"sceneImgOriginal"`
-	} `json:"defaultSceneAssets"`
+	DefaultSceneAssets *SceneAssets `json:"defaultSceneAssets,omitempty"`
+}
+
+// SceneAssets holds the assets of the scene, see UploadSceneImage.
+// There is no documentation for this object.
+type SceneAssets struct {
+	// Link to the uploaded and resized scene image.
+	SceneIMG string `json:"sceneImg"`
+	// Link to the uploaded original scene image.
+	SceneIMGOriginal string `json:"sceneImgOriginal"`
+}
+
+// UploadSceneImage uploads the image of the scene (e.g. its background) and
+// returns the links to it. The image is streamed as a multipart form unless
+// retries are enabled with WithRetries, which buffer the request body. The
+// content type is the MIME type of the image, e.g. "image/png". The image is
+// not read after the method returns.
+// There is no documentation for this method.
+func (c Client) UploadSceneImage(
+	ctx context.Context,
+	sceneID string,
+	img io.Reader,
+	contentType string,
+) (SceneAssets, error) {
+	if sceneID == "" {
+		return SceneAssets{}, errors.New("scene id is required")
+	}
+
+	if contentType == "" {
+		return SceneAssets{}, errors.New("content type is required")
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="image"; filename="image"`)
+		h.Set("Content-Type", contentType)
+
+		part, err := mw.CreatePart(h)
+		if err == nil {
+			_, err = io.Copy(part, img)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(errors.Wrap(err, "writing image"))
+	}()
+
+	r, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		apiStudioV1.JoinPath(sceneID+":uploadSceneImage").String(),
+		pr,
+	)
+	if err != nil {
+		pr.CloseWithError(err)
+		<-done
+		return SceneAssets{}, errors.WithStack(err)
+	}
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := sendStudioAPIRequest[SceneAssets](c, r)
+
+	// Unblocks the writer if the request failed before the body was read.
+	pr.CloseWithError(io.ErrClosedPipe)
+	<-done
+
+	return resp, err
 }
 
 // SceneCharacterReference holds scene character reference.
@@ -0,0 +1,135 @@
+package inworld
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUpdateSceneDescriptionOnly(t *testing.T) {
+	stored := Scene{
+		Name:            "workspaces/w/scenes/s",
+		Description:     "old",
+		TimePeriod:      "1920s",
+		CommonKnowledge: []string{"workspaces/w/common-knowledge/k"},
+		Characters:      []SceneCharacterReference{{Character: "workspaces/w/characters/c"}},
+		SceneTriggers:   []SceneTrigger{{Trigger: "workspaces/w/triggers/t"}},
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("method is %s, want PATCH", r.Method)
+		}
+
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(body) != 1 || body["description"] == nil {
+			t.Errorf("body is %v, want only the description", body)
+		}
+
+		// The server keeps the fields missing in the request.
+		updated := stored
+		if err := json.Unmarshal(body["description"], &updated.Description); err != nil {
+			t.Error(err)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(updated)
+	})
+
+	s, err := c.UpdateScene(context.Background(), stored.Name, Scene{Description: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Description != "new" || len(s.Characters) != 1 || s.TimePeriod != stored.TimePeriod {
+		t.Errorf("unexpected scene %+v", s)
+	}
+}
+
+func TestUploadSceneImage(t *testing.T) {
+	img := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0xff}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/studio/v1/workspaces/w/scenes/s:uploadSceneImage" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/form-data" {
+			t.Errorf("content type is %q", r.Header.Get("Content-Type"))
+			return
+		}
+
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if part.FormName() != "image" || part.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("unexpected part header %v", part.Header)
+		}
+
+		got, err := io.ReadAll(part)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !bytes.Equal(got, img) {
+			t.Errorf("image is %x, want %x", got, img)
+		}
+
+		if _, err = mr.NextPart(); err != io.EOF {
+			t.Errorf("unexpected part after the image: %v", err)
+		}
+
+		_, _ = w.Write([]byte(`{"sceneImg":"https://example.com/s.png"}`))
+	})
+
+	assets, err := c.UploadSceneImage(context.Background(), "workspaces/w/scenes/s", bytes.NewReader(img), "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assets.SceneIMG != "https://example.com/s.png" {
+		t.Errorf("unexpected assets %+v", assets)
+	}
+}
+
+// slowReader counts the reads in progress.
+type slowReader struct{ reading atomic.Int32 }
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	r.reading.Add(1)
+	defer r.reading.Add(-1)
+	time.Sleep(time.Millisecond)
+	return len(p), nil
+}
+
+func TestUploadSceneImageFailure(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	img := &slowReader{}
+	if _, err := c.UploadSceneImage(context.Background(), "workspaces/w/scenes/s", io.LimitReader(img, 10<<20), "image/png"); err == nil {
+		t.Error("no error")
+	}
+	if img.reading.Load() != 0 {
+		t.Error("the image is read after the method returned")
+	}
+}
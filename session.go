@@ -0,0 +1,188 @@
+package inworld
+
+import (
+	"context"
+	stderrors "errors"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// SessionHandle keeps the client-side state of a session opened with
+// OpenSession and sends messages to it. It is safe for concurrent use.
+type SessionHandle struct {
+	client Client
+	// Request the session was opened with, used to reopen it.
+	req OpenSessionRequest
+
+	mu      sync.Mutex
+	session Session
+	// The last text sent to each session character.
+	lastText map[string]string
+	// The conversation held through the handle, used to continue it after
+	// reconnecting.
+	dialog []DialogPhrase
+}
+
+// OpenSessionHandle opens a session like OpenSession does and returns a handle
+// to interact with it.
+func (c Client) OpenSessionHandle(ctx context.Context, req OpenSessionRequest) (*SessionHandle, error) {
+	s, err := c.OpenSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	h := c.NewSessionHandle(s)
+	h.req = req
+	return h, nil
+}
+
+// NewSessionHandle returns a handle of the already opened session.
+func (c Client) NewSessionHandle(s Session) *SessionHandle {
+	return &SessionHandle{
+		client:   c,
+		req:      OpenSessionRequest{Name: s.LoadedScene},
+		session:  s,
+		lastText: map[string]string{},
+	}
+}
+
+// Session returns the session as it was returned by OpenSession or by the
+// last Reconnect.
+func (h *SessionHandle) Session() Session {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.session
+}
+
+// ID returns the id of the session, the last segment of Session.Name.
+func (h *SessionHandle) ID() string { return path.Base(h.Session().Name) }
+
+// SendText sends the text to the session character. Format of the session
+// character:
+// workspaces/{workspace}/sessions/{session}/sessionCharacters/{session_character}
+func (h *SessionHandle) SendText(ctx context.Context, sessionCharacter, text string) (Interaction, error) {
+	i, err := h.client.SendText(ctx, SendTextRequest{
+		SessionID:        h.ID(),
+		SessionCharacter: sessionCharacter,
+		Text:             text,
+	})
+	if err != nil {
+		return Interaction{}, err
+	}
+
+	h.mu.Lock()
+	h.lastText[sessionCharacter] = text
+	h.dialog = append(h.dialog, DialogPhrase{Talker: DialogParticipantPlayer, Phrase: text})
+	if reply := strings.Join(i.TextList, " "); reply != "" {
+		h.dialog = append(h.dialog, DialogPhrase{Talker: DialogParticipantCharacter, Phrase: reply})
+	}
+	h.mu.Unlock()
+
+	return i, nil
+}
+
+// Reconnect opens a new session in place of the dropped one. The API can't
+// resume a session by its name, so the conversation held through this handle
+// is passed to the new session as SessionContinuation and resumed reports
+// whether there was anything to continue. Session characters of the new
+// session have new names, they can be found in Session; the texts to
+// regenerate are carried over.
+func (h *SessionHandle) Reconnect(ctx context.Context) (resumed bool, err error) {
+	// The lock isn't held while the session is opened, so other methods of the
+	// handle are not blocked by the request.
+	h.mu.Lock()
+	req := h.req
+	if len(h.dialog) > 0 {
+		req.SessionContinuation = &SessionContinuation{
+			PreviousDialog: &PreviousDialog{Phrases: slices.Clone(h.dialog)},
+		}
+	}
+	h.mu.Unlock()
+
+	s, err := h.client.OpenSession(ctx, req)
+	if err != nil {
+		return false, errors.Wrap(err, "reopening session")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Session characters are matched by the characters they refer to.
+	renamed := make(map[string]string, len(s.SessionCharacters))
+	for _, old := range h.session.SessionCharacters {
+		for _, sc := range s.SessionCharacters {
+			if sc.Character == old.Character {
+				renamed[old.Name] = sc.Name
+			}
+		}
+	}
+
+	lastText := make(map[string]string, len(h.lastText))
+	for name, text := range h.lastText {
+		if newName, ok := renamed[name]; ok {
+			lastText[newName] = text
+		}
+	}
+
+	h.session, h.lastText = s, lastText
+
+	return req.SessionContinuation != nil, nil
+}
+
+// RegenerateLastResponse asks the session character for another response to
+// the last text sent to it through this handle. The API has no native support
+// for regeneration, so the text is sent once again: the previous response is
+// not discarded server-side and stays in the character's memory of the
+// conversation. Only the texts sent with SessionHandle.SendText are tracked.
+func (h *SessionHandle) RegenerateLastResponse(ctx context.Context, sessionCharacter string) (Interaction, error) {
+	if sessionCharacter == "" {
+		return Interaction{}, stderrors.New("session character is required")
+	}
+
+	h.mu.Lock()
+	text, ok := h.lastText[sessionCharacter]
+	h.mu.Unlock()
+
+	if !ok {
+		return Interaction{}, errors.Errorf("no text was sent to %s through this session handle", sessionCharacter)
+	}
+
+	return h.SendText(ctx, sessionCharacter, text)
+}
+
+// SimulateScene opens a session on the scene and sends the turns one by one to
+// its first character, returning the interactions in the order of the turns.
+// It's meant for testing scenes, the API has no dedicated preview endpoint.
+// Sessions can't be closed with the API, the opened one expires on its own.
+// On error the interactions received so far are returned along with it.
+// Format of the scene: workspaces/{workspace}/scenes/{scene}
+func (c Client) SimulateScene(ctx context.Context, sceneID string, turns []string) ([]Interaction, error) {
+	if _, err := resourceWorkspace(sceneID, ResourceKindScene); err != nil {
+		return nil, err
+	}
+
+	h, err := c.OpenSessionHandle(ctx, OpenSessionRequest{Name: sceneID})
+	if err != nil {
+		return nil, errors.Wrap(err, "opening session")
+	}
+
+	characters := h.Session().SessionCharacters
+	if len(characters) == 0 {
+		return nil, errors.Errorf("scene %s has no characters", sceneID)
+	}
+
+	res := make([]Interaction, 0, len(turns))
+	for i, text := range turns {
+		interaction, err := h.SendText(ctx, characters[0].Name, text)
+		if err != nil {
+			return res, errors.Wrapf(err, "sending turn %d", i)
+		}
+		res = append(res, interaction)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,192 @@
+package inworld
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSessionHandleReconnect(t *testing.T) {
+	const scene = "workspaces/w/scenes/s"
+
+	var (
+		mu            sync.Mutex
+		sessions      int
+		continuations []*SessionContinuation
+		sent          []string
+	)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":openSession"):
+			var req OpenSessionRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+				return
+			}
+			continuations = append(continuations, req.SessionContinuation)
+
+			sessions++
+			name := fmt.Sprintf("workspaces/w/sessions/s%d", sessions)
+			_ = json.NewEncoder(w).Encode(Session{
+				Name:        name,
+				LoadedScene: scene,
+				SessionCharacters: []SessionCharacter{
+					{Name: name + "/sessionCharacters/ada", Character: "workspaces/w/characters/ada"},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, ":sendText"):
+			var req SendTextRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+				return
+			}
+			sent = append(sent, strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, ":sendText"), "/v1/")+" "+req.Text)
+			_ = json.NewEncoder(w).Encode(Interaction{TextList: []string{"Hello,", "traveler."}})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+
+	h, err := c.OpenSessionHandle(ctx, OpenSessionRequest{Name: scene})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldCharacter := h.Session().SessionCharacters[0].Name
+	if _, err = h.SendText(ctx, oldCharacter, "Hi!"); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := h.Reconnect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resumed {
+		t.Error("the conversation is not resumed")
+	}
+	if id := h.ID(); id != "s2" {
+		t.Errorf("session id is %q, want s2", id)
+	}
+
+	want := []*SessionContinuation{nil, {PreviousDialog: &PreviousDialog{Phrases: []DialogPhrase{
+		{Talker: DialogParticipantPlayer, Phrase: "Hi!"},
+		{Talker: DialogParticipantCharacter, Phrase: "Hello, traveler."},
+	}}}}
+	if !reflect.DeepEqual(continuations, want) {
+		t.Errorf("continuations are %+v, want %+v", continuations, want)
+	}
+
+	// The last text is carried over to the renamed session character.
+	if _, err = h.RegenerateLastResponse(ctx, oldCharacter); err == nil {
+		t.Error("regenerated the response of the session character of the dropped session")
+	}
+	if _, err = h.RegenerateLastResponse(ctx, h.Session().SessionCharacters[0].Name); err != nil {
+		t.Fatal(err)
+	}
+
+	wantSent := []string{
+		"workspaces/w/sessions/s1/sessionCharacters/ada Hi!",
+		"workspaces/w/sessions/s2/sessionCharacters/ada Hi!",
+	}
+	if !reflect.DeepEqual(sent, wantSent) {
+		t.Errorf("sent %q, want %q", sent, wantSent)
+	}
+}
+
+func TestSimulateScene(t *testing.T) {
+	const scene = "workspaces/w/scenes/s"
+
+	newClient := func(t *testing.T, characters []SessionCharacter, failTurn int) (Client, *[]string) {
+		var (
+			mu   sync.Mutex
+			sent []string
+		)
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if strings.HasSuffix(r.URL.Path, ":openSession") {
+				_ = json.NewEncoder(w).Encode(Session{Name: "workspaces/w/sessions/s1", SessionCharacters: characters})
+				return
+			}
+
+			var req SendTextRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+				return
+			}
+			if len(sent) == failTurn {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			sent = append(sent, strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, ":sendText"), "/v1/")+" "+req.Text)
+			_ = json.NewEncoder(w).Encode(Interaction{TextList: []string{"re: " + req.Text}})
+		})
+		return c, &sent
+	}
+
+	characters := []SessionCharacter{
+		{Name: "workspaces/w/sessions/s1/sessionCharacters/ada"},
+		{Name: "workspaces/w/sessions/s1/sessionCharacters/bob"},
+	}
+
+	t.Run("turns", func(t *testing.T) {
+		c, sent := newClient(t, characters, -1)
+
+		res, err := c.SimulateScene(context.Background(), scene, []string{"Hi!", "Who are you?"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(res) != 2 || res[0].TextList[0] != "re: Hi!" || res[1].TextList[0] != "re: Who are you?" {
+			t.Errorf("unexpected interactions %+v", res)
+		}
+
+		want := []string{
+			"workspaces/w/sessions/s1/sessionCharacters/ada Hi!",
+			"workspaces/w/sessions/s1/sessionCharacters/ada Who are you?",
+		}
+		if !reflect.DeepEqual(*sent, want) {
+			t.Errorf("sent %q, want %q", *sent, want)
+		}
+	})
+
+	t.Run("failed turn", func(t *testing.T) {
+		c, _ := newClient(t, characters, 1)
+
+		res, err := c.SimulateScene(context.Background(), scene, []string{"Hi!", "Who are you?", "Bye."})
+		if !errors.Is(err, ErrUnavailable) || !strings.Contains(err.Error(), "sending turn 1") {
+			t.Errorf("error is %v, want ErrUnavailable of turn 1", err)
+		}
+		if len(res) != 1 {
+			t.Errorf("got %d interactions, want the one received before the error", len(res))
+		}
+	})
+
+	t.Run("no characters", func(t *testing.T) {
+		c, _ := newClient(t, nil, -1)
+
+		if _, err := c.SimulateScene(context.Background(), scene, []string{"Hi!"}); err == nil {
+			t.Error("no error")
+		}
+	})
+
+	t.Run("not a scene", func(t *testing.T) {
+		c, _ := newClient(t, characters, -1)
+
+		if _, err := c.SimulateScene(context.Background(), "workspaces/w/characters/ada", []string{"Hi!"}); err == nil {
+			t.Error("no error")
+		}
+	})
+}
@@ -3,6 +3,7 @@ package inworld
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -11,6 +12,11 @@ import (
 // https://docs.inworld.ai/docs/tutorial-api/reference
 
 // SimpleSendText rpc to send simple text request directly to single character.
+//
+// A request without SessionID makes the server create a new session, so the
+// character doesn't remember previous turns. To continue the conversation,
+// pass Interaction.SessionID of the previous response, or use
+// SimpleSendTextInSession or SimpleConversation, which do it automatically.
 func (c Client) SimpleSendText(ctx context.Context, req SimpleSendTextRequest) (Interaction, error) {
 	if req.Character == "" {
 		return Interaction{}, errors.New("character is required")
@@ -20,6 +26,10 @@ func (c Client) SimpleSendText(ctx context.Context, req SimpleSendTextRequest) (
 		return Interaction{}, errors.New("text is required")
 	}
 
+	if req.LanguageCode != "" && !validLanguageCode(req.LanguageCode) {
+		return Interaction{}, errors.Errorf("invalid language code %q", req.LanguageCode)
+	}
+
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
@@ -39,6 +49,12 @@ func (c Client) OpenSession(ctx context.Context, req OpenSessionRequest) (Sessio
 		return Session{}, errors.New("name is required")
 	}
 
+	if req.SessionContinuation != nil {
+		if err := req.SessionContinuation.Validate(); err != nil {
+			return Session{}, err
+		}
+	}
+
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
@@ -53,6 +69,12 @@ func (c Client) OpenSession(ctx context.Context, req OpenSessionRequest) (Sessio
 }
 
 // SendText rpc to send text to the previously opened session.
+//
+// The response is returned once the character has finished the whole turn: the
+// REST API has no partial responses, so there is no way to get the first part
+// of the text earlier. Applications sensitive to latency should use the
+// streaming (websocket) API of the Inworld SDKs, which delivers the text and
+// audio chunk by chunk.
 func (c Client) SendText(ctx context.Context, req SendTextRequest) (Interaction, error) {
 	if req.SessionID == "" {
 		return Interaction{}, errors.New("session id is required")
@@ -66,6 +88,10 @@ func (c Client) SendText(ctx context.Context, req SendTextRequest) (Interaction,
 		return Interaction{}, errors.New("text is required")
 	}
 
+	if req.LanguageCode != "" && !validLanguageCode(req.LanguageCode) {
+		return Interaction{}, errors.Errorf("invalid language code %q", req.LanguageCode)
+	}
+
 	r, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
@@ -79,7 +105,14 @@ func (c Client) SendText(ctx context.Context, req SendTextRequest) (Interaction,
 	return sendSimpleAPIRequest[Interaction](c, r, req.SessionID)
 }
 
-// SendTrigger rpc to send trigger event to the previously opened session.
+// SendTrigger rpc to send trigger event to the previously opened session. The
+// trigger may be either a full resource name or a bare id, which is qualified
+// with the workspace of the session character (or the one set by
+// WithDefaultWorkspace if it can't be determined), see TriggerResourceName.
+//
+// The API has no signal for the end of the conversation. To let the character
+// wrap up in character, define a goal activated by a trigger (e.g.
+// "conversation_end") and send it with SendTrigger, see GoalsConfig.
 func (c Client) SendTrigger(ctx context.Context, req SendTriggerRequest) (Interaction, error) {
 	if req.SessionID == "" {
 		return Interaction{}, errors.New("session id is required")
@@ -93,6 +126,12 @@ func (c Client) SendTrigger(ctx context.Context, req SendTriggerRequest) (Intera
 		return Interaction{}, errors.New("trigger is required")
 	}
 
+	trigger, err := c.qualifyTrigger(req.TriggerEvent.Trigger, req.SessionCharacter)
+	if err != nil {
+		return Interaction{}, err
+	}
+	req.TriggerEvent.Trigger = trigger
+
 	for _, p := range req.TriggerEvent.Parameters {
 		if p.Name == "" {
 			return Interaction{}, errors.New("parameter name is required")
@@ -117,6 +156,11 @@ func (c Client) SendTrigger(ctx context.Context, req SendTriggerRequest) (Intera
 
 // SimpleSendTextRequest request message for
 // [Sessions.SimpleSendText][ai.inworld.engine.v1.Sessions.SimpleSendText].
+// The API has no field to tag interactions with custom metadata, such tags can
+// be sent as gRPC metadata with WithMetadata, but they are not returned with
+// the Interaction, so they must be kept on the client for analytics.
+// The API has no per-request length of the response, it is configured for the
+// character with CharacterDescription.DialogResponseLength.
 // https://docs.inworld.ai/docs/tutorial-api/reference/#simplesendtextrequest
 type SimpleSendTextRequest struct {
 	// Full resource name of the character to send text to. Format
@@ -130,6 +174,10 @@ type SimpleSendTextRequest struct {
 	EndUserID string `json:"endUserId,omitempty"` // Optional.
 	// Full display name of the end user, this will be used by character in dialog.
 	EndUserFullname string `json:"endUserFullname,omitempty"` // Optional.
+	// BCP-47 code of the language the character should respond in, e.g. "en-US".
+	// The language configured for the character is used when empty.
+	// There is no documentation for this field.
+	LanguageCode string `json:"languageCode,omitempty"` // Optional.
 }
 
 // OpenSessionRequest request message for
@@ -141,7 +189,74 @@ type OpenSessionRequest struct {
 	// workspaces/{workspace}/characters/{character}.
 	Name string `json:"name"` // Required.
 	// Configuration of the experience consumer. End User information.
-	User EndUserConfig `json:"user,omitempty"` // Optional.
+	User EndUserConfig `json:"user"` // Optional.
+	// State of the previous session to continue the conversation with, e.g.
+	// after loading a saved game.
+	// There is no documentation for this field.
+	SessionContinuation *SessionContinuation `json:"sessionContinuation,omitempty"` // Optional.
+}
+
+// SessionContinuation describes the conversation the opened session continues.
+// Either of the fields is enough, the previous state is preferred by the server
+// when both are set.
+// There is no documentation for this object.
+type SessionContinuation struct {
+	// Phrases of the previous conversation in chronological order.
+	PreviousDialog *PreviousDialog `json:"previousDialog,omitempty"` // Optional.
+	// Opaque state of the previous session as it was returned by the server.
+	PreviousState Base64Bytes `json:"previousState,omitempty"` // Optional.
+}
+
+// PreviousDialog is the conversation the session is continued from.
+// There is no documentation for this object.
+type PreviousDialog struct {
+	Phrases []DialogPhrase `json:"phrases"` // Required.
+}
+
+// DialogPhrase is a single phrase of the PreviousDialog.
+// There is no documentation for this object.
+type DialogPhrase struct {
+	// Who said the phrase.
+	Talker DialogParticipant `json:"talker"` // Required.
+	// Text of the phrase.
+	Phrase string `json:"phrase"` // Required.
+}
+
+// DialogParticipant describes who said the DialogPhrase.
+type DialogParticipant string
+
+const (
+	DialogParticipantPlayer    DialogParticipant = "PLAYER"
+	DialogParticipantCharacter DialogParticipant = "CHARACTER"
+)
+
+// Validate checks that the continuation is well-formed.
+func (s SessionContinuation) Validate() error {
+	if s.PreviousDialog == nil && len(s.PreviousState) == 0 {
+		return errors.New("session continuation requires previous dialog or previous state")
+	}
+
+	if s.PreviousDialog == nil {
+		return nil
+	}
+
+	if len(s.PreviousDialog.Phrases) == 0 {
+		return errors.New("previous dialog requires at least one phrase")
+	}
+
+	for i, p := range s.PreviousDialog.Phrases {
+		switch p.Talker {
+		case DialogParticipantPlayer, DialogParticipantCharacter:
+		default:
+			return errors.Errorf("phrase %d: unknown talker %q", i, p.Talker)
+		}
+
+		if p.Phrase == "" {
+			return errors.Errorf("phrase %d: phrase is required", i)
+		}
+	}
+
+	return nil
 }
 
 // EndUserConfig represents the configuration of the end user of the system.
@@ -161,6 +276,9 @@ type EndUserConfig struct {
 
 // SendTextRequest request message for
 // [Sessions.SendText][ai.inworld.engine.v1.Sessions.SendText].
+// Custom metadata can be sent like with SimpleSendTextRequest.
+// The API has no per-request length of the response, it is configured for the
+// character with CharacterDescription.DialogResponseLength.
 // https://docs.inworld.ai/docs/tutorial-api/reference/#sendtextrequest
 type SendTextRequest struct {
 	// Unique id of the session.
@@ -170,6 +288,10 @@ type SendTextRequest struct {
 	SessionCharacter string `json:"-"` // Required.
 	// Text message to send to the character.
 	Text string `json:"text"` // Required.
+	// BCP-47 code of the language the character should respond in, e.g. "en-US".
+	// The language configured for the character is used when empty.
+	// There is no documentation for this field.
+	LanguageCode string `json:"languageCode,omitempty"` // Optional.
 }
 
 // SendTriggerRequest request message for
@@ -196,6 +318,53 @@ type TriggerEvent struct {
 	Parameters []Parameter `json:"parameters,omitempty"` // Optional.
 }
 
+// CustomEvent is the action performed by the character, e.g. the trigger sent
+// by a goal.
+// There is no documentation for this object.
+type CustomEvent struct {
+	// Name of the event. Format: workspaces/{workspace}/triggers/{eventId}
+	CustomEvent string      `json:"customEvent"`
+	Parameters  []Parameter `json:"parameters"`
+}
+
+// Param returns the value of the parameter with the given name.
+func (e TriggerEvent) Param(name string) (string, bool) {
+	for _, p := range e.Parameters {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// TriggerResourceName returns the resource name of the trigger with the given
+// id. Format: workspaces/{workspace}/triggers/{trigger}
+func TriggerResourceName(workspaceID, triggerID string) string {
+	return "workspaces/" + workspaceID + "/triggers/" + triggerID
+}
+
+// qualifyTrigger returns the resource name of the trigger given either as a
+// full name or as a bare id.
+func (c Client) qualifyTrigger(trigger, sessionCharacter string) (string, error) {
+	if strings.Contains(trigger, "/") {
+		if _, err := resourceWorkspace(trigger, ResourceKindTrigger); err != nil {
+			return "", err
+		}
+		return trigger, nil
+	}
+
+	workspaceID := c.defaultWorkspace
+	if parts := strings.Split(sessionCharacter, "/"); len(parts) > 2 && parts[0] == "workspaces" && parts[1] != "" {
+		workspaceID = parts[1]
+	}
+
+	if workspaceID == "" {
+		return "", errors.Errorf("workspace of trigger %q is unknown, use its full name or WithDefaultWorkspace", trigger)
+	}
+
+	return TriggerResourceName(workspaceID, trigger), nil
+}
+
 // Parameter supports string values for now, but can be expanded in future on
 // as-needed basis.
 // https://docs.inworld.ai/docs/tutorial-api/reference/#parameter
@@ -206,7 +375,9 @@ type Parameter struct {
 	Value string `json:"value"` // Required.
 }
 
-// Session response message for LoadScene RPC.
+// Session response message for LoadScene RPC. The API has no method to get an
+// opened session, so the session must be kept by the client to know its
+// characters later, e.g. with SessionHandle.
 // https://docs.inworld.ai/docs/tutorial-api/reference/#session
 type Session struct {
 	// Full resource name of the session. Format:
@@ -263,19 +434,20 @@ type Interaction struct {
 	// Active trigger.
 	ActiveTriggers []TriggerEvent `json:"activeTriggers"`
 
-	// There is no documentation for these fields.
-	CustomEvent struct {
-		CustomEvent string `json:"customEvent"`
-		Parameters  []any  `json:"parameters"`
-	} `json:"customEvent"`
-	Parameters map[string]any `json:"parameters"`
+	// There is no documentation for these fields. Numbers of the parameters
+	// are decoded as json.Number, see Interaction.IntParam.
+	CustomEvent CustomEvent    `json:"customEvent"`
+	Parameters  map[string]any `json:"parameters"`
+	// Usage or billing information of the interaction, nil if the server
+	// didn't report it.
+	Usage *Usage `json:"usage,omitempty"`
 }
 
 // Emotion describes emotion of the session character.
 // https://docs.inworld.ai/docs/tutorial-api/reference/#interactionemotion
 type Emotion struct {
 	Behavior SpaffCode `json:"behavior"`
-	Strength string    `json:"strength"`
+	Strength Strength  `json:"strength"`
 }
 
 // SpaffCode describes character behavior affected by emotions. For more details
@@ -307,6 +479,55 @@ const (
 	ScaffCodeJoy           SpaffCode = "JOY"
 )
 
+// Known reports whether the code is one of the declared constants. Codes added
+// to the API later are decoded as is, but are not known.
+func (s SpaffCode) Known() bool {
+	_, ok := spaffCategories[s]
+	return ok
+}
+
+// Category classifies the code as positive, negative or neutral. Unspecified
+// and unknown codes are classified as SpaffCategoryUnknown.
+func (s SpaffCode) Category() SpaffCategory {
+	if c, ok := spaffCategories[s]; ok {
+		return c
+	}
+	return SpaffCategoryUnknown
+}
+
+// SpaffCategory is the kind of the emotion described by SpaffCode.
+type SpaffCategory string
+
+const (
+	SpaffCategoryUnknown  SpaffCategory = "UNKNOWN"
+	SpaffCategoryNeutral  SpaffCategory = "NEUTRAL"
+	SpaffCategoryPositive SpaffCategory = "POSITIVE"
+	SpaffCategoryNegative SpaffCategory = "NEGATIVE"
+)
+
+var spaffCategories = map[SpaffCode]SpaffCategory{
+	SpaffCodeUnspecified:   SpaffCategoryUnknown,
+	ScaffCodeNeutral:       SpaffCategoryNeutral,
+	ScaffCodeDisgust:       SpaffCategoryNegative,
+	ScaffCodeContempt:      SpaffCategoryNegative,
+	ScaffCodeBelligerence:  SpaffCategoryNegative,
+	ScaffCodeDomineering:   SpaffCategoryNegative,
+	ScaffCodeCriticism:     SpaffCategoryNegative,
+	ScaffCodeAnger:         SpaffCategoryNegative,
+	ScaffCodeTension:       SpaffCategoryNegative,
+	ScaffCodeTenseHumor:    SpaffCategoryNegative,
+	ScaffCodeDefensiveness: SpaffCategoryNegative,
+	ScaffCodeWhining:       SpaffCategoryNegative,
+	ScaffCodeSadness:       SpaffCategoryNegative,
+	ScaffCodeStonewalling:  SpaffCategoryNegative,
+	ScaffCodeInterest:      SpaffCategoryPositive,
+	ScaffCodeValidation:    SpaffCategoryPositive,
+	ScaffCodeAffection:     SpaffCategoryPositive,
+	ScaffCodeHumor:         SpaffCategoryPositive,
+	ScaffCodeSurprise:      SpaffCategoryPositive,
+	ScaffCodeJoy:           SpaffCategoryPositive,
+}
+
 // Strength describes strength of the emotion.
 // https://docs.inworld.ai/docs/tutorial-api/reference/#interactionemotionstrength
 type Strength string
@@ -318,6 +539,17 @@ const (
 	StrengthNormal      Strength = "NORMAL"
 )
 
+// Known reports whether the strength is one of the declared constants.
+// Strengths added to the API later are decoded as is, but are not known.
+func (s Strength) Known() bool {
+	switch s {
+	case StrengthUnspecified, StrengthWeak, StrengthStrong, StrengthNormal:
+		return true
+	default:
+		return false
+	}
+}
+
 // RelationshipUpdate shows changes in relationship based on latest interaction.
 // https://docs.inworld.ai/docs/tutorial-api/reference/#relationshipupdate
 type RelationshipUpdate struct {
@@ -332,3 +564,28 @@ type RelationshipUpdate struct {
 	// How attracted the character is to the user.
 	Attraction int `json:"attraction"`
 }
+
+// validLanguageCode reports whether code is a well-formed BCP-47 language tag:
+// a primary language subtag of 2-3 (or 5-8) letters followed by optional
+// alphanumeric subtags of 1-8 characters separated by hyphens.
+func validLanguageCode(code string) bool {
+	for i, tag := range strings.Split(code, "-") {
+		if len(tag) == 0 || len(tag) > 8 {
+			return false
+		}
+
+		for _, r := range tag {
+			isLetter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+			isDigit := r >= '0' && r <= '9'
+			if !isLetter && !(isDigit && i > 0) {
+				return false
+			}
+		}
+
+		if i == 0 && (len(tag) < 2 || len(tag) == 4) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,74 @@
+package inworld
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUndeclaredSpaffCode(t *testing.T) {
+	var i Interaction
+	if err := json.Unmarshal([]byte(`{"emotion":{"behavior":"AWE","strength":"STRONG"}}`), &i); err != nil {
+		t.Fatal(err)
+	}
+
+	code := i.Emotion.Behavior
+	if code != "AWE" {
+		t.Errorf("code is %q, want AWE", code)
+	}
+	if code.Known() {
+		t.Error("undeclared code is known")
+	}
+	if got := code.Category(); got != SpaffCategoryUnknown {
+		t.Errorf("category is %q, want %q", got, SpaffCategoryUnknown)
+	}
+
+	b, err := json.Marshal(i.Emotion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"behavior":"AWE","strength":"STRONG"}`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
+
+func TestDeclaredSpaffCodes(t *testing.T) {
+	tests := map[SpaffCode]SpaffCategory{
+		SpaffCodeUnspecified: SpaffCategoryUnknown,
+		ScaffCodeNeutral:     SpaffCategoryNeutral,
+		ScaffCodeAnger:       SpaffCategoryNegative,
+		ScaffCodeJoy:         SpaffCategoryPositive,
+	}
+
+	for code, want := range tests {
+		if !code.Known() {
+			t.Errorf("%s is not known", code)
+		}
+		if got := code.Category(); got != want {
+			t.Errorf("category of %s is %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestValidLanguageCode(t *testing.T) {
+	for code, want := range map[string]bool{
+		"en":              true,
+		"en-US":           true,
+		"zh-Hant-TW":      true,
+		"es-419":          true,
+		"haw":             true,
+		"de-CH-1996":      true,
+		"":                false,
+		"e":               false,
+		"engl":            false,
+		"en-":             false,
+		"-US":             false,
+		"en--US":          false,
+		"en_US":           false,
+		"12-US":           false,
+		"en-US-abcdefghi": false,
+	} {
+		if got := validLanguageCode(code); got != want {
+			t.Errorf("validLanguageCode(%q) = %t, want %t", code, got, want)
+		}
+	}
+}
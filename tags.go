@@ -0,0 +1,101 @@
+package inworld
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Tag is a label attached to characters, scenes and common knowledge.
+// There is no documentation for this object. Depending on the resource, the
+// API returns tags either as plain strings or as objects, both forms are
+// accepted when decoding. A tag is encoded in the form it was received in,
+// so the resource is sent back the way the server returned it. Tags created
+// by the caller are encoded as objects.
+type Tag struct {
+	// Identifier of the tag.
+	ID string `json:"id,omitempty"` // Optional.
+	// Human-readable name of the tag.
+	Name string `json:"name,omitempty"` // Optional.
+	// Color of the tag in studio, for example "#FF0000".
+	Color string `json:"color,omitempty"` // Optional.
+
+	// Whether the tag was received as a plain string.
+	plain bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	if t.plain && t.ID == "" && t.Color == "" {
+		b, err := json.Marshal(t.Name)
+		return b, errors.Wrap(err, "json marshaling tag")
+	}
+
+	type tag Tag
+	b, err := json.Marshal(tag(t))
+	return b, errors.Wrap(err, "json marshaling tag")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Tag) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*t = Tag{Name: name, plain: true}
+		return nil
+	}
+
+	type tag Tag
+	if err := json.Unmarshal(b, (*tag)(t)); err != nil {
+		return errors.Wrap(err, "json unmarshaling tag")
+	}
+
+	return nil
+}
+
+// equal reports whether t and other refer to the same tag. Tags are matched by
+// id when both have it, and by name otherwise.
+func (t Tag) equal(other Tag) bool {
+	if t.ID != "" && other.ID != "" {
+		return t.ID == other.ID
+	}
+	return t.Name == other.Name
+}
+
+// AddUserTags appends tags to UserTags skipping the ones already present.
+// Changes are not saved until the character is updated.
+func (c *Character) AddUserTags(tags ...Tag) { c.UserTags = addTags(c.UserTags, tags) }
+
+// RemoveUserTags removes tags from UserTags. Changes are not saved until the
+// character is updated.
+func (c *Character) RemoveUserTags(tags ...Tag) { c.UserTags = removeTags(c.UserTags, tags) }
+
+// HasUserTag reports whether the character has the given user tag.
+func (c Character) HasUserTag(tag Tag) bool { return indexTag(c.UserTags, tag) >= 0 }
+
+func addTags(dst, tags []Tag) []Tag {
+	for _, t := range tags {
+		if indexTag(dst, t) < 0 {
+			dst = append(dst, t)
+		}
+	}
+	return dst
+}
+
+func removeTags(dst, tags []Tag) []Tag {
+	res := make([]Tag, 0, len(dst))
+	for _, t := range dst {
+		if indexTag(tags, t) < 0 {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func indexTag(tags []Tag, tag Tag) int {
+	for i, t := range tags {
+		if t.equal(tag) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,55 @@
+package inworld
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTagRoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "strings", in: `["hero","villain"]`, want: `["hero","villain"]`},
+		{
+			name: "objects",
+			in:   `[{"id":"1","name":"hero","color":"#FF0000"},{"name":"villain"}]`,
+			want: `[{"id":"1","name":"hero","color":"#FF0000"},{"name":"villain"}]`,
+		},
+		{name: "mixed", in: `["hero",{"id":"2","name":"villain"}]`, want: `["hero",{"id":"2","name":"villain"}]`},
+		{name: "empty", in: `[]`, want: `[]`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var tags []Tag
+			if err := json.Unmarshal([]byte(tt.in), &tags); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(tags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("got %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagCreatedByCaller(t *testing.T) {
+	var ch Character
+	if err := json.Unmarshal([]byte(`{"userTags":["hero"]}`), &ch); err != nil {
+		t.Fatal(err)
+	}
+
+	ch.AddUserTags(Tag{Name: "hero"}, Tag{Name: "villain"})
+
+	b, err := json.Marshal(ch.UserTags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `["hero",{"name":"villain"}]`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
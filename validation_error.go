@@ -0,0 +1,102 @@
+package inworld
+
+import (
+	stderrors "errors"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FieldViolation describes a single invalid field of the request, see
+// Error.FieldViolations.
+type FieldViolation struct {
+	// Path to the field, e.g. "character.defaultCharacterDescription.givenName".
+	Field string
+	// Why the field is invalid.
+	Description string
+}
+
+// FieldViolations returns the invalid fields listed in the google.rpc.BadRequest
+// details of the error, nil if there are none.
+func (e *Error) FieldViolations() []FieldViolation {
+	var res []FieldViolation
+	for _, d := range e.Details {
+		m, ok := d.(map[string]any)
+		if !ok || !strings.HasSuffix(stringValue(m["@type"]), "google.rpc.BadRequest") {
+			continue
+		}
+
+		violations, _ := m["fieldViolations"].([]any)
+		for _, v := range violations {
+			fv, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			res = append(res, FieldViolation{
+				Field:       stringValue(fv["field"]),
+				Description: stringValue(fv["description"]),
+			})
+		}
+	}
+
+	return res
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// ValidationError is returned by the create and update methods when the server
+// rejects the request listing the invalid fields. It wraps the *Error, so
+// errors.Is(err, ErrInvalidArgument) holds for it.
+type ValidationError struct {
+	Err        *Error
+	Violations []FieldViolation
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Err.Message)
+	for i, v := range e.Violations {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(v.Field + ": " + v.Description)
+	}
+	return b.String()
+}
+
+// Unwrap returns the original error.
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// For returns the description of the violation of the field with the given
+// path, e.g. "character.defaultCharacterDescription.givenName".
+func (e *ValidationError) For(fieldPath string) (string, bool) {
+	for _, v := range e.Violations {
+		if v.Field == fieldPath {
+			return v.Description, true
+		}
+	}
+	return "", false
+}
+
+// withValidationError converts the *Error listing the invalid fields to
+// *ValidationError.
+func withValidationError[T any](v T, err error) (T, error) {
+	var e *Error
+	if !stderrors.As(err, &e) {
+		return v, err
+	}
+
+	violations := e.FieldViolations()
+	if len(violations) == 0 {
+		return v, err
+	}
+
+	return v, errors.WithStack(&ValidationError{Err: e, Violations: violations})
+}
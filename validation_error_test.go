@@ -0,0 +1,79 @@
+package inworld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// badRequestBody is a 400 response of CreateCharacter as it is returned by
+// the server.
+const badRequestBody = `{
+	"code": 3,
+	"message": "invalid character",
+	"details": [
+		{
+			"@type": "type.googleapis.com/google.rpc.BadRequest",
+			"fieldViolations": [
+				{"field": "character.defaultCharacterDescription.givenName", "description": "must not be empty"},
+				{"field": "character.defaultCharacterDescription.pronoun", "description": "unknown pronoun"}
+			]
+		},
+		{
+			"@type": "type.googleapis.com/google.rpc.RequestInfo",
+			"requestId": "0f4b7c"
+		}
+	]
+}`
+
+func TestValidationError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(badRequestBody))
+	})
+
+	_, err := c.CreateCharacter(context.Background(), "w", Character{})
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("error %v is not *ValidationError", err)
+	}
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("error %v is not ErrInvalidArgument", err)
+	}
+
+	if d, ok := ve.For("character.defaultCharacterDescription.givenName"); !ok || d != "must not be empty" {
+		t.Errorf("givenName violation is %q, %v", d, ok)
+	}
+	if d, ok := ve.For("character.defaultCharacterDescription.pronoun"); !ok || d != "unknown pronoun" {
+		t.Errorf("pronoun violation is %q, %v", d, ok)
+	}
+	if d, ok := ve.For("character.name"); ok {
+		t.Errorf("unexpected violation of name %q", d)
+	}
+
+	const want = "invalid character: " +
+		"character.defaultCharacterDescription.givenName: must not be empty; " +
+		"character.defaultCharacterDescription.pronoun: unknown pronoun"
+	if got := ve.Error(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestErrorWithoutViolations(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":3,"message":"invalid character"}`))
+	})
+
+	_, err := c.CreateCharacter(context.Background(), "w", Character{})
+
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		t.Errorf("error without violations is %T", ve)
+	}
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("error %v is not ErrInvalidArgument", err)
+	}
+}
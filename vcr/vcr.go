@@ -0,0 +1,309 @@
+// Package vcr records HTTP interactions of the inworld client to a cassette
+// file and replays them on subsequent runs. It allows snapshotting the real API
+// behavior once and running tests against it offline.
+//
+// The Recorder implements http.RoundTripper, so it is plugged in through the
+// transport of the http.Client passed to inworld.NewClient:
+//
+//	rec, err := vcr.New("testdata/characters.jsonl", vcr.ModeAuto, nil)
+//	if err != nil {
+//		return err
+//	}
+//	client := inworld.NewClient(simpleAPIKey, studioAPIKey, http.Client{Transport: rec})
+//
+// Interactions are matched by method, path with query and a hash of the
+// request body. Authorization and cookie headers are never written to the
+// cassette.
+//
+// The cassette is a JSON Lines file, each recorded interaction is appended to
+// it as one line, so recording doesn't rewrite the file and an interrupted run
+// keeps what was recorded so far. Bodies are stored base64 encoded, since they
+// may be binary (e.g. images).
+//
+// To re-record a cassette either delete the file and run with ModeAuto, or run
+// with ModeRecord, which overwrites the existing file. Real API keys are needed
+// only while recording.
+package vcr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInteractionNotFound is returned in replay mode when the cassette has no
+// recorded interaction matching the request.
+var ErrInteractionNotFound = stderrors.New("vcr: interaction not found in cassette")
+
+// Mode defines how the Recorder treats requests.
+type Mode int
+
+const (
+	// ModeAuto replays interactions when the cassette file exists and records
+	// them otherwise.
+	ModeAuto Mode = iota
+	// ModeRecord always sends requests to the real server and overwrites the
+	// cassette.
+	ModeRecord
+	// ModeReplay never sends requests to the real server, all responses are
+	// taken from the cassette.
+	ModeReplay
+)
+
+// Interaction is a single recorded request/response pair.
+type Interaction struct {
+	// Key used to match requests. Format: {method} {path?query} {body sha256}.
+	Key      string   `json:"key"`
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request with scrubbed authorization headers.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording and replaying interactions. It is
+// safe for concurrent use.
+type Recorder struct {
+	path   string
+	replay bool
+	next   http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	// Whether the cassette was truncated by the first recorded interaction.
+	truncated bool
+	// Number of already replayed interactions per key. Identical requests are
+	// replayed in the order they were recorded.
+	replayed map[string]int
+}
+
+// New creates a Recorder storing interactions in the file at path. Requests
+// are sent using next when recording, http.DefaultTransport is used if next is
+// nil.
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if path == "" {
+		return nil, stderrors.New("cassette path is required")
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{path: path, next: next, replayed: map[string]int{}}
+
+	switch mode {
+	case ModeRecord:
+		return r, nil
+	case ModeReplay:
+		r.replay = true
+	case ModeAuto:
+		_, err := os.Stat(path)
+		if stderrors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		r.replay = true
+	default:
+		return nil, errors.Errorf("unknown mode %d", mode)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening cassette")
+	}
+	defer f.Close()
+
+	d := json.NewDecoder(f)
+	for {
+		var it Interaction
+		err = d.Decode(&it)
+		if stderrors.Is(err, io.EOF) {
+			return r, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "json unmarshaling interaction %d of cassette", len(r.interactions)+1)
+		}
+		r.interactions = append(r.interactions, it)
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading request body")
+		}
+		if err = req.Body.Close(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	key := Key(req.Method, req.URL.RequestURI(), body)
+
+	if r.replay {
+		return r.find(req, key)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := r.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, combine(errors.Wrap(err, "reading response body"), resp.Body.Close())
+	}
+	if err = resp.Body.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	err = r.record(Interaction{
+		Key: key,
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: scrub(req.Header),
+			Body:   body,
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     scrub(resp.Header),
+			Body:       respBody,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) find(req *http.Request, key string) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	skip := r.replayed[key]
+	var last *Interaction
+	for i := range r.interactions {
+		it := &r.interactions[i]
+		if it.Key != key {
+			continue
+		}
+
+		last = it
+		if skip == 0 {
+			break
+		}
+		skip--
+	}
+
+	if last == nil {
+		return nil, errors.Wrap(ErrInteractionNotFound, key)
+	}
+
+	// When all recorded interactions with this key were replayed, the last one
+	// is returned again.
+	r.replayed[key]++
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", last.Response.StatusCode, http.StatusText(last.Response.StatusCode)),
+		StatusCode:    last.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        last.Response.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(last.Response.Body)),
+		ContentLength: int64(len(last.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(it Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.Marshal(it)
+	if err != nil {
+		return errors.Wrap(err, "json marshaling interaction")
+	}
+
+	// The cassette of a previous recording is overwritten.
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if !r.truncated {
+		flag |= os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(r.path, flag, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "opening cassette")
+	}
+
+	_, err = f.Write(append(b, '\n'))
+	if err = combine(err, f.Close()); err != nil {
+		return errors.Wrap(err, "writing cassette")
+	}
+
+	r.truncated = true
+	r.interactions = append(r.interactions, it)
+	return nil
+}
+
+// Key returns the key used to match a request with recorded interactions.
+func Key(method, requestURI string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return method + " " + requestURI + " " + hex.EncodeToString(sum[:])
+}
+
+// scrub returns a copy of the header without credentials.
+func scrub(h http.Header) http.Header {
+	res := h.Clone()
+	for k := range res {
+		switch {
+		case strings.Contains(strings.ToLower(k), "authorization"),
+			http.CanonicalHeaderKey(k) == "Cookie",
+			http.CanonicalHeaderKey(k) == "Set-Cookie":
+			delete(res, k)
+		}
+	}
+	return res
+}
+
+func combine(err1, err2 error) error {
+	if err2 == nil {
+		return err1
+	}
+	return errors.WithStack(stderrors.Join(err1, err2))
+}
@@ -0,0 +1,146 @@
+package vcr
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	// Not valid UTF-8, it must survive the round trip byte for byte.
+	image := []byte{0x89, 'P', 'N', 'G', 0xff, 0xfe, 0x00, 0x80}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		w.Header().Set("Content-Type", "image/png")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write(image)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	send := func(t *testing.T, rt http.RoundTripper, method string, body []byte) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(method, srv.URL+"/studio/v1/image?size=2", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Basic secret-key")
+		req.Header.Set("Cookie", "session=secret-session")
+
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	rec, err := New(path, ModeAuto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		resp := send(t, rec, method, image)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(b, []byte("\n")); n != 2 {
+		t.Errorf("cassette has %d lines, want one per interaction", n)
+	}
+	if bytes.Contains(b, []byte("secret")) {
+		t.Errorf("cassette contains credentials:\n%s", b)
+	}
+
+	// The server is not needed to replay.
+	srv.Close()
+
+	rep, err := New(path, ModeAuto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		method     string
+		wantStatus string
+	}{
+		{method: http.MethodGet, wantStatus: "200 OK"},
+		{method: http.MethodPost, wantStatus: "201 Created"},
+	} {
+		t.Run(tt.method, func(t *testing.T) {
+			resp := send(t, rep, tt.method, image)
+			defer resp.Body.Close()
+
+			if resp.Status != tt.wantStatus {
+				t.Errorf("status is %q, want %q", resp.Status, tt.wantStatus)
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != "image/png" {
+				t.Errorf("content type is %q", ct)
+			}
+			if c := resp.Header.Get("Set-Cookie"); c != "" {
+				t.Errorf("cookie %q is replayed", c)
+			}
+
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, image) {
+				t.Errorf("body is %x, want %x", got, image)
+			}
+		})
+	}
+
+	if _, err = rep.RoundTrip(httptest.NewRequest(http.MethodDelete, srv.URL+"/studio/v1/image", nil)); !stderrors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("error is %v, want ErrInteractionNotFound", err)
+	}
+}
+
+func TestRecordOverwritesCassette(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	if err := os.WriteFile(path, []byte(`{"key":"GET /old"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/new", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(b)), "\n"); len(lines) != 1 || !strings.Contains(lines[0], "GET /new") {
+		t.Errorf("unexpected cassette:\n%s", b)
+	}
+}
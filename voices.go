@@ -0,0 +1,90 @@
+package inworld
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// ListVoices returns the voices available to the characters of the workspace,
+// the voices of all providers are returned unless filtered with WithTTSType.
+// The API doesn't support filtering, so the voices are filtered on the client.
+// Voices of all providers come from the same endpoint, none of them has a
+// separate sub-endpoint: voices of Eleven Labs are listed there too, but only
+// if the workspace is integrated with it, VoiceID of the voice is set in
+// StudioBaseVoice.TtsMetadata.
+// There is no documentation for this method.
+func (c Client) ListVoices(ctx context.Context, workspaceID string, opts ...VoiceOption) ([]StudioBaseVoice, error) {
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var o voiceOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		apiStudioV1.JoinPath("workspaces", workspaceID, "voices").String(),
+		http.NoBody,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resp, err := sendStudioAPIRequest[listVoicesResponse](c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(o.ttsTypes) == 0 {
+		return resp.Voices, nil
+	}
+
+	var res []StudioBaseVoice
+	for _, v := range resp.Voices {
+		if o.ttsTypes[v.TTSType] {
+			res = append(res, v)
+		}
+	}
+
+	return res, nil
+}
+
+// listVoicesResponse is the response of listing voices.
+type listVoicesResponse struct {
+	Voices []StudioBaseVoice `json:"voices"`
+}
+
+// VoiceOption filters the voices returned by ListVoices.
+type VoiceOption func(*voiceOptions)
+
+type voiceOptions struct {
+	ttsTypes map[TTSType]bool
+}
+
+// WithTTSType returns only the voices of the given providers, may be passed
+// several times.
+func WithTTSType(types ...TTSType) VoiceOption {
+	return func(o *voiceOptions) {
+		if o.ttsTypes == nil {
+			o.ttsTypes = map[TTSType]bool{}
+		}
+		for _, t := range types {
+			o.ttsTypes[t] = true
+		}
+	}
+}
+
+// GroupVoicesByTTSType groups the voices by their providers.
+func GroupVoicesByTTSType(voices []StudioBaseVoice) map[TTSType][]StudioBaseVoice {
+	res := map[TTSType][]StudioBaseVoice{}
+	for _, v := range voices {
+		res[v.TTSType] = append(res[v.TTSType], v)
+	}
+	return res
+}
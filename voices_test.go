@@ -0,0 +1,36 @@
+package inworld
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestListVoicesWithTTSType(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/studio/v1/workspaces/w/voices" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"voices":[
+			{"name":"workspaces/w/voices/Alex","ttsType":"TTS_TYPE_INWORLD"},
+			{"name":"workspaces/w/voices/Rachel","ttsType":"TTS_TYPE_ELEVEN_LABS"},
+			{"name":"workspaces/w/voices/Wavenet","ttsType":"TTS_TYPE_GOOGLE"}
+		]}`))
+	})
+
+	voices, err := c.ListVoices(context.Background(), "w", WithTTSType(TTSTypeElevenLabs), WithTTSType(TTSTypeGoogle))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(voices) != 2 || voices[0].TTSType != TTSTypeElevenLabs || voices[1].TTSType != TTSTypeGoogle {
+		t.Errorf("unexpected voices %+v", voices)
+	}
+	// All providers are listed by a single request.
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+}
@@ -0,0 +1,111 @@
+package inworld
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// GetWorkspace returns the workspace with its display name and, when provided
+// by the server, the number of resources created in it.
+// There is no documentation for this method.
+func (c Client) GetWorkspace(ctx context.Context, workspaceID string) (Workspace, error) {
+	workspaceID, err := c.workspace(workspaceID)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	r, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		apiStudioV1.JoinPath("workspaces", workspaceID).String(),
+		http.NoBody,
+	)
+	if err != nil {
+		return Workspace{}, errors.WithStack(err)
+	}
+
+	return sendStudioAPIRequest[Workspace](c, r)
+}
+
+// workspace returns workspaceID or, if it's empty, the default workspace set
+// with WithDefaultWorkspace.
+func (c Client) workspace(workspaceID string) (string, error) {
+	if workspaceID == "" {
+		workspaceID = c.defaultWorkspace
+	}
+
+	if workspaceID == "" {
+		return "", errors.New("workspace id is required")
+	}
+
+	return workspaceID, nil
+}
+
+// ErrConnection is returned by Ping when the API can't be reached.
+var ErrConnection = stderrors.New("inworld api is unreachable")
+
+// Ping checks the studio API key and connectivity with a cheap read-only call
+// (listing a single workspace). Invalid credentials are reported with the
+// *Error matching ErrUnauthenticated or ErrPermissionDenied, network failures
+// with an error matching ErrConnection. Other errors, e.g. failures to obtain
+// the credentials or to decode the response, are returned as is.
+// There is no documentation for the endpoint being used.
+func (c Client) Ping(ctx context.Context) error {
+	u := apiStudioV1.JoinPath("workspaces")
+	u.RawQuery = "pageSize=1"
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = sendStudioAPIRequest[struct{}](c, r)
+
+	var (
+		e  *Error
+		ue *url.Error
+		ne net.Error
+	)
+	switch {
+	case err == nil:
+		return nil
+	case stderrors.Is(err, ErrUnauthenticated), stderrors.Is(err, ErrPermissionDenied):
+		return errors.Wrap(err, "checking studio api credentials")
+	case stderrors.As(err, &e), ctx.Err() != nil:
+		return err
+	case stderrors.As(err, &ue), stderrors.As(err, &ne):
+		return errors.WithStack(stderrors.Join(ErrConnection, err))
+	default:
+		return err
+	}
+}
+
+// Workspace represents a workspace containing characters, scenes and common
+// knowledge.
+// There is no documentation for this object.
+type Workspace struct {
+	// Full resource name of the workspace. Format: workspaces/{workspace}
+	Name string `json:"name"`
+	// User specified name.
+	DisplayName string `json:"displayName"`
+	// Default settings applied to the resources created in the workspace.
+	DefaultSettings map[string]any `json:"defaultSettings,omitempty"`
+	// Number of resources in the workspace.
+	Meta *WorkspaceMeta `json:"meta,omitempty"`
+}
+
+// WorkspaceMeta describes the statistics of the workspace.
+// There is no documentation for this object.
+type WorkspaceMeta struct {
+	// Number of characters created in the workspace.
+	TotalCharacters int32 `json:"totalCharacters"`
+	// Number of scenes created in the workspace.
+	TotalScenes int32 `json:"totalScenes"`
+	// Number of common knowledge entries created in the workspace.
+	TotalCommonKnowledge int32 `json:"totalCommonKnowledge"`
+}
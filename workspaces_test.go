@@ -0,0 +1,64 @@
+package inworld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	closedURL, err := url.Parse(closed.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errToken := errors.New("token is not available")
+
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		opts       []Option
+		wantErr    bool
+		want       error
+		connection bool
+	}{
+		{name: "ok", status: http.StatusOK, body: `{"workspaces":[]}`},
+		{name: "unauthenticated", status: http.StatusUnauthorized, wantErr: true, want: ErrUnauthenticated},
+		{name: "permission denied", status: http.StatusForbidden, wantErr: true, want: ErrPermissionDenied},
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantErr: true, want: ErrUnavailable},
+		{name: "unreachable", opts: []Option{WithBaseURL(closedURL)}, wantErr: true, connection: true},
+		{
+			name:    "credentials",
+			opts:    []Option{WithStudioTokenSource(func(context.Context) (string, error) { return "", errToken })},
+			wantErr: true,
+			want:    errToken,
+		},
+		{name: "malformed body", status: http.StatusOK, body: `{"workspaces":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}, tt.opts...)
+
+			err := c.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error is %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("error is %v, want %v", err, tt.want)
+			}
+			if got := errors.Is(err, ErrConnection); got != tt.connection {
+				t.Errorf("error %v matches ErrConnection: %v, want %v", err, got, tt.connection)
+			}
+		})
+	}
+}